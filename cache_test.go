@@ -0,0 +1,80 @@
+package incache
+
+import "testing"
+
+// TestBuild_EvictTypes verifies that every EvictType produces a working Cache[K, V].
+func TestBuild_EvictTypes(t *testing.T) {
+	types := []EvictType{Manual, LRU, LFU, FIFO}
+
+	for _, et := range types {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, "one")
+		if v, ok := c.Get(1); !ok || v != "one" {
+			t.Errorf("%s: expected to get 'one', got %q (ok=%v)", et, v, ok)
+		}
+	}
+}
+
+// TestCrossType_TransferTo checks that any builder-produced cache can transfer its
+// entries into any other, since they all satisfy the same Cache[K, V] interface.
+func TestCrossType_TransferTo(t *testing.T) {
+	builders := map[EvictType]func() Cache[int, string]{
+		Manual: func() Cache[int, string] { return New[int, string](10).Build() },
+		LRU:    func() Cache[int, string] { b := New[int, string](10); b.EvictType(LRU); return b.Build() },
+		LFU:    func() Cache[int, string] { b := New[int, string](10); b.EvictType(LFU); return b.Build() },
+	}
+
+	for srcType, newSrc := range builders {
+		for dstType, newDst := range builders {
+			src := newSrc()
+			dst := newDst()
+
+			src.Set(1, "one")
+			src.Set(2, "two")
+			src.TransferTo(dst)
+
+			if _, ok := src.Get(1); ok {
+				t.Errorf("%s -> %s: expected key 1 to be removed from source after transfer", srcType, dstType)
+			}
+
+			if v, ok := dst.Get(1); !ok || v != "one" {
+				t.Errorf("%s -> %s: expected to get 'one' from destination, got %q (ok=%v)", srcType, dstType, v, ok)
+			}
+
+			if v, ok := dst.Get(2); !ok || v != "two" {
+				t.Errorf("%s -> %s: expected to get 'two' from destination, got %q (ok=%v)", srcType, dstType, v, ok)
+			}
+		}
+	}
+}
+
+// TestCrossType_CopyTo checks that any builder-produced cache can copy its entries
+// into any other without removing them from the source.
+func TestCrossType_CopyTo(t *testing.T) {
+	builders := map[EvictType]func() Cache[int, string]{
+		Manual: func() Cache[int, string] { return New[int, string](10).Build() },
+		LRU:    func() Cache[int, string] { b := New[int, string](10); b.EvictType(LRU); return b.Build() },
+		LFU:    func() Cache[int, string] { b := New[int, string](10); b.EvictType(LFU); return b.Build() },
+	}
+
+	for srcType, newSrc := range builders {
+		for dstType, newDst := range builders {
+			src := newSrc()
+			dst := newDst()
+
+			src.Set(1, "one")
+			src.CopyTo(dst)
+
+			if v, ok := src.Get(1); !ok || v != "one" {
+				t.Errorf("%s -> %s: expected source to still have 'one', got %q (ok=%v)", srcType, dstType, v, ok)
+			}
+
+			if v, ok := dst.Get(1); !ok || v != "one" {
+				t.Errorf("%s -> %s: expected to get 'one' from destination, got %q (ok=%v)", srcType, dstType, v, ok)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package incache
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// countMinSketch is a probabilistic frequency estimator backing the W-TinyLFU admission
+// policy: it answers "roughly how many times has this key been seen?" in O(1) time and
+// fixed memory, trading a small amount of over-counting from hash collisions for not
+// having to track every key's exact count. Counters are periodically halved so a key's
+// estimated frequency reflects recent behavior rather than its entire lifetime.
+type countMinSketch struct {
+	rows       [4][]uint8
+	mask       uint64
+	additions  uint64
+	sampleSize uint64
+}
+
+func newCountMinSketch(capacity uint) *countMinSketch {
+	width := nextPowerOfTwo(capacity)
+	if width < 16 {
+		width = 16
+	}
+	sampleSize := uint64(capacity) * 10
+	if sampleSize == 0 {
+		sampleSize = 160
+	}
+	cms := &countMinSketch{
+		mask:       uint64(width - 1),
+		sampleSize: sampleSize,
+	}
+	for i := range cms.rows {
+		cms.rows[i] = make([]uint8, width)
+	}
+	return cms
+}
+
+func nextPowerOfTwo(n uint) uint {
+	p := uint(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func (c *countMinSketch) hash(key any) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v", key)
+	return h.Sum64()
+}
+
+func (c *countMinSketch) indexFor(h uint64, row int) uint64 {
+	mixed := h ^ (uint64(row+1) * 0x9E3779B97F4A7C15)
+	mixed ^= mixed >> 33
+	mixed *= 0xff51afd7ed558ccd
+	mixed ^= mixed >> 33
+	return mixed & c.mask
+}
+
+// add records one more observation of key, halving every counter once the number of
+// additions since the last halving reaches 10x the sketch's configured capacity.
+func (c *countMinSketch) add(key any) {
+	h := c.hash(key)
+	for row := 0; row < 4; row++ {
+		idx := c.indexFor(h, row)
+		if c.rows[row][idx] < 255 {
+			c.rows[row][idx]++
+		}
+	}
+	c.additions++
+	if c.additions >= c.sampleSize {
+		c.reset()
+	}
+}
+
+// estimate returns the minimum counter across all rows for key, which is always >= the
+// true count and converges to it as collisions become rare relative to key cardinality.
+func (c *countMinSketch) estimate(key any) uint8 {
+	h := c.hash(key)
+	min := uint8(255)
+	for row := 0; row < 4; row++ {
+		if v := c.rows[row][c.indexFor(h, row)]; v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func (c *countMinSketch) reset() {
+	for row := range c.rows {
+		for i, v := range c.rows[row] {
+			c.rows[row][i] = v / 2
+		}
+	}
+	c.additions = 0
+}
@@ -0,0 +1,39 @@
+package incache
+
+import "testing"
+
+func TestCountMinSketch_EstimateTracksFrequency(t *testing.T) {
+	cms := newCountMinSketch(1000)
+
+	for i := 0; i < 5; i++ {
+		cms.add("hot")
+	}
+	cms.add("cold")
+
+	if got := cms.estimate("hot"); got < 5 {
+		t.Errorf("estimate(hot) = %d, want >= 5", got)
+	}
+	if got := cms.estimate("cold"); got < 1 {
+		t.Errorf("estimate(cold) = %d, want >= 1", got)
+	}
+	if got := cms.estimate("never-added"); got != 0 {
+		t.Errorf("estimate(never-added) = %d, want 0", got)
+	}
+}
+
+func TestCountMinSketch_ResetHalvesCounters(t *testing.T) {
+	cms := newCountMinSketch(4) // small sampleSize (40) so reset triggers quickly
+
+	// Exactly sampleSize additions: the last one triggers a single halving, 40 -> 20.
+	for i := uint64(0); i < cms.sampleSize; i++ {
+		cms.add("key")
+	}
+
+	got := cms.estimate("key")
+	if got != 20 {
+		t.Errorf("estimate(key) = %d, want 20 after one halving of 40 additions", got)
+	}
+	if cms.additions >= cms.sampleSize {
+		t.Errorf("expected additions to have been reset below sampleSize, got %d/%d", cms.additions, cms.sampleSize)
+	}
+}
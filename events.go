@@ -0,0 +1,190 @@
+package incache
+
+import "sync"
+
+// EvictionReason describes why a key-value pair left the cache.
+type EvictionReason int
+
+const (
+	// ReasonExpired means the entry was removed because its TTL elapsed.
+	ReasonExpired EvictionReason = iota
+	// ReasonCapacity means the entry was removed to make room under the configured size.
+	ReasonCapacity
+	// ReasonDeleted means the entry was removed by an explicit Delete call, or replaced by Set.
+	ReasonDeleted
+	// ReasonPurged means the entry was removed because the cache was purged.
+	ReasonPurged
+	// ReasonTransferred means the entry left this cache because TransferTo moved it to
+	// another cache; it is not gone, just relocated.
+	ReasonTransferred
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonExpired:
+		return "expired"
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonDeleted:
+		return "deleted"
+	case ReasonPurged:
+		return "purged"
+	case ReasonTransferred:
+		return "transferred"
+	default:
+		return "unknown"
+	}
+}
+
+type insertionEvent[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+type evictionEvent[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// eventDispatcher fans insertion/eviction events out to registered listeners on a single
+// background goroutine, so firing an event from under a cache's write lock can never let a
+// user callback deadlock the cache by re-entering it.
+type eventDispatcher[K comparable, V any] struct {
+	mu         sync.Mutex
+	insertions map[int]func(K, V)
+	evictions  map[int]func(K, V, EvictionReason)
+	nextID     int
+
+	insertCh chan insertionEvent[K, V]
+	evictCh  chan evictionEvent[K, V]
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newEventDispatcher[K comparable, V any]() *eventDispatcher[K, V] {
+	d := &eventDispatcher[K, V]{
+		insertions: make(map[int]func(K, V)),
+		evictions:  make(map[int]func(K, V, EvictionReason)),
+		insertCh:   make(chan insertionEvent[K, V], 256),
+		evictCh:    make(chan evictionEvent[K, V], 256),
+		stopCh:     make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+func (d *eventDispatcher[K, V]) run() {
+	for {
+		select {
+		case e := <-d.insertCh:
+			d.mu.Lock()
+			listeners := make([]func(K, V), 0, len(d.insertions))
+			for _, fn := range d.insertions {
+				listeners = append(listeners, fn)
+			}
+			d.mu.Unlock()
+			for _, fn := range listeners {
+				fn(e.key, e.value)
+			}
+		case e := <-d.evictCh:
+			d.mu.Lock()
+			listeners := make([]func(K, V, EvictionReason), 0, len(d.evictions))
+			for _, fn := range d.evictions {
+				listeners = append(listeners, fn)
+			}
+			d.mu.Unlock()
+			for _, fn := range listeners {
+				fn(e.key, e.value, e.reason)
+			}
+		case <-d.stopCh:
+			// Drain events already queued before stop was requested (e.g. the burst of
+			// evictions Purge fires right before stopping the dispatcher) so callers never
+			// observe events silently dropped on shutdown.
+			for {
+				select {
+				case e := <-d.insertCh:
+					d.mu.Lock()
+					listeners := make([]func(K, V), 0, len(d.insertions))
+					for _, fn := range d.insertions {
+						listeners = append(listeners, fn)
+					}
+					d.mu.Unlock()
+					for _, fn := range listeners {
+						fn(e.key, e.value)
+					}
+				case e := <-d.evictCh:
+					d.mu.Lock()
+					listeners := make([]func(K, V, EvictionReason), 0, len(d.evictions))
+					for _, fn := range d.evictions {
+						listeners = append(listeners, fn)
+					}
+					d.mu.Unlock()
+					for _, fn := range listeners {
+						fn(e.key, e.value, e.reason)
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *eventDispatcher[K, V]) onInsertion(fn func(K, V)) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	id := d.nextID
+	d.nextID++
+	d.insertions[id] = fn
+	return id
+}
+
+func (d *eventDispatcher[K, V]) removeInsertionListener(id int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.insertions, id)
+}
+
+func (d *eventDispatcher[K, V]) onEviction(fn func(K, V, EvictionReason)) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	id := d.nextID
+	d.nextID++
+	d.evictions[id] = fn
+	return id
+}
+
+func (d *eventDispatcher[K, V]) removeEvictionListener(id int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.evictions, id)
+}
+
+// fireInsertion enqueues an insertion event, applying backpressure to the caller (typically
+// blocking under the cache's write lock) rather than dropping the event when the queue is
+// full: a caller relying on OnEviction to release a resource (an fd, a DB handle) must see
+// every eviction fire exactly once, so silently discarding events under load is not an
+// option. The only event this ever drops is one fired after stop has been called, since
+// nothing is left to dispatch it; such drops can only happen during a cache's own shutdown
+// (Purge, finalize), by which point every pre-shutdown event has already been delivered.
+func (d *eventDispatcher[K, V]) fireInsertion(k K, v V) {
+	select {
+	case d.insertCh <- insertionEvent[K, V]{key: k, value: v}:
+	case <-d.stopCh:
+	}
+}
+
+// fireEviction enqueues an eviction event. See fireInsertion for the backpressure/shutdown semantics.
+func (d *eventDispatcher[K, V]) fireEviction(k K, v V, reason EvictionReason) {
+	select {
+	case d.evictCh <- evictionEvent[K, V]{key: k, value: v, reason: reason}:
+	case <-d.stopCh:
+	}
+}
+
+func (d *eventDispatcher[K, V]) stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+}
@@ -0,0 +1,150 @@
+package incache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEvents_OnInsertionAndOnEviction_Capacity(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](2)
+		b.EvictType(et)
+		c := b.Build()
+
+		var mu sync.Mutex
+		var inserted []int
+		var evicted []EvictionReason
+
+		c.OnInsertion(func(k int, v string) {
+			mu.Lock()
+			inserted = append(inserted, k)
+			mu.Unlock()
+		})
+		c.OnEviction(func(k int, v string, reason EvictionReason) {
+			mu.Lock()
+			evicted = append(evicted, reason)
+			mu.Unlock()
+		})
+
+		c.Set(1, "one")
+		c.Set(2, "two")
+		c.Set(3, "three") // capacity-2 cache: evicts one entry
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(inserted)
+			mu.Unlock()
+			if n == 3 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(inserted) != 3 {
+			t.Errorf("%s: expected 3 insertions, got %d", et, len(inserted))
+		}
+		if len(evicted) != 1 || evicted[0] != ReasonCapacity {
+			t.Errorf("%s: expected one ReasonCapacity eviction, got %v", et, evicted)
+		}
+	}
+}
+
+func TestEvents_OnEviction_Deleted(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+		c.OnEviction(func(k int, v string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		c.Set(1, "one")
+		c.Delete(1)
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(reasons)
+			mu.Unlock()
+			if n >= 1 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(reasons) != 1 || reasons[0] != ReasonDeleted {
+			t.Errorf("%s: expected one ReasonDeleted eviction, got %v", et, reasons)
+		}
+	}
+}
+
+// TestEvents_NoLossUnderLoad exercises the chunk2-4 use case of releasing a resource (an
+// fd, a DB handle) on eviction: every eviction must reach the listener exactly once even
+// when far more than the dispatcher's internal queue depth fire in a burst, so the
+// dispatcher must apply backpressure instead of dropping events once its queue fills up.
+func TestEvents_NoLossUnderLoad(t *testing.T) {
+	const n = 1000
+	c := New[int, int](1).Build() // capacity 1: every Set past the first evicts the previous key
+
+	var mu sync.Mutex
+	evictions := 0
+	c.OnEviction(func(k, v int, reason EvictionReason) {
+		mu.Lock()
+		evictions++
+		mu.Unlock()
+	})
+
+	for i := 0; i < n; i++ {
+		c.Set(i, i)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		got := evictions
+		mu.Unlock()
+		if got == n-1 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if evictions != n-1 {
+		t.Errorf("expected all %d evictions to be delivered, got %d", n-1, evictions)
+	}
+}
+
+func TestEvents_RemoveListener(t *testing.T) {
+	c := New[int, string](10).Build()
+
+	var calls int
+	var mu sync.Mutex
+	id := c.OnInsertion(func(k int, v string) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	})
+	c.RemoveInsertionListener(id)
+
+	c.Set(1, "one")
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 0 {
+		t.Errorf("expected removed listener not to be called, got %d calls", calls)
+	}
+}
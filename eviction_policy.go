@@ -0,0 +1,705 @@
+package incache
+
+import (
+	"container/list"
+	"math/rand"
+)
+
+// EvictionPolicy selects which pluggable eviction policy a PolicyCache uses. It is
+// analogous to EvictType, but chooses the eviction strategy for the single generic
+// PolicyCache implementation rather than which dedicated Cache[K,V] type to build.
+type EvictionPolicy string
+
+const (
+	// PolicyLRU evicts the least-recently-accessed key, same ordering as LRUCache.
+	PolicyLRU EvictionPolicy = "policy-lru"
+	// PolicyLFU evicts the least-frequently-accessed key, using an O(1) frequency-bucketed
+	// structure rather than LFUCache's linear-scan move.
+	PolicyLFU EvictionPolicy = "policy-lfu"
+	// PolicyARC adapts between recency and frequency using the T1/T2/B1/B2 ghost-list
+	// scheme described in Megiddo & Modha's "ARC: A Self-Tuning, Low Overhead Replacement
+	// Cache" (FAST 2003).
+	PolicyARC EvictionPolicy = "policy-arc"
+	// PolicyFIFO evicts the oldest-inserted key regardless of access pattern, same
+	// ordering as FIFOCache.
+	PolicyFIFO EvictionPolicy = "policy-fifo"
+	// PolicyRandom evicts a uniformly random key. It never promotes or demotes on
+	// access, trading hit rate for the lowest possible per-access bookkeeping cost.
+	PolicyRandom EvictionPolicy = "policy-random"
+	// PolicyTinyLFU evicts using the W-TinyLFU scheme (a small LRU window admitting new
+	// keys into a segmented-LRU main cache, gated by a Count-Min Sketch frequency
+	// estimate), the same design as TinyLFUCache but expressed as a policy[K] so it can
+	// run inside the shared PolicyCache core instead of its own dedicated cache type.
+	PolicyTinyLFU EvictionPolicy = "policy-tinylfu"
+)
+
+// policy decides what PolicyCache evicts and in what order, decoupling that decision
+// from PolicyCache's own bookkeeping (the value map, weights, events, stats). Callers
+// (PolicyCache) hold their own lock around every call, so implementations don't need to
+// be safe for concurrent use.
+//
+// The contract between PolicyCache and a policy: OnInsert is called exactly once per
+// logical insertion of a new key, and PolicyCache always calls Remove for a key before
+// re-inserting it (a Set that overwrites an existing key looks like a Remove followed by
+// an OnInsert, not an update in place) so a policy never has to special-case "already
+// present" inside OnInsert. Evict(n) is called only when PolicyCache has determined, from
+// its own weight/capacity bookkeeping, that n entries must go; it returns the keys chosen,
+// in the order they should be evicted, and may return fewer than n if it has nothing left.
+type policy[K comparable] interface {
+	// OnAccess records a read of key, e.g. promoting it in whatever ordering the policy
+	// uses to decide eviction. key is always currently present in the cache.
+	OnAccess(key K)
+
+	// OnInsert records the insertion of a new key. Per the contract above, key is never
+	// already tracked by the policy when this is called.
+	OnInsert(key K)
+
+	// Remove stops tracking key, e.g. because it was deleted, expired, or is about to be
+	// replaced by a fresh OnInsert. A no-op if key isn't tracked.
+	Remove(key K)
+
+	// Evict selects up to n keys to remove, in eviction order, and stops tracking them.
+	Evict(n int) []K
+}
+
+// newPolicy builds the policy implementation named by p, defaulting to PolicyLRU for an
+// unrecognized or zero-value EvictionPolicy. capacity sizes PolicyARC's ghost lists.
+func newPolicy[K comparable](p EvictionPolicy, capacity uint) policy[K] {
+	switch p {
+	case PolicyLFU:
+		return newLFUPolicy[K]()
+	case PolicyARC:
+		return newARCPolicy[K](capacity)
+	case PolicyFIFO:
+		return newFIFOPolicy[K]()
+	case PolicyRandom:
+		return newRandomPolicy[K]()
+	case PolicyTinyLFU:
+		return newTinyLFUPolicy[K](capacity)
+	default:
+		return newLRUPolicy[K]()
+	}
+}
+
+// lruPolicy evicts the least-recently-accessed key: OnAccess and OnInsert both move the
+// key to the front of an ordinary doubly-linked list, and Evict takes from the back.
+type lruPolicy[K comparable] struct {
+	l    *list.List
+	elem map[K]*list.Element
+}
+
+func newLRUPolicy[K comparable]() *lruPolicy[K] {
+	return &lruPolicy[K]{l: list.New(), elem: make(map[K]*list.Element)}
+}
+
+func (p *lruPolicy[K]) OnInsert(key K) {
+	p.elem[key] = p.l.PushFront(key)
+}
+
+func (p *lruPolicy[K]) OnAccess(key K) {
+	if e, ok := p.elem[key]; ok {
+		p.l.MoveToFront(e)
+	}
+}
+
+func (p *lruPolicy[K]) Remove(key K) {
+	if e, ok := p.elem[key]; ok {
+		p.l.Remove(e)
+		delete(p.elem, key)
+	}
+}
+
+func (p *lruPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n; i++ {
+		b := p.l.Back()
+		if b == nil {
+			break
+		}
+		key := b.Value.(K)
+		p.l.Remove(b)
+		delete(p.elem, key)
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// fifoPolicy evicts the oldest-inserted key: unlike lruPolicy, OnAccess never reorders
+// the list, so only insertion order determines eviction order.
+type fifoPolicy[K comparable] struct {
+	l    *list.List
+	elem map[K]*list.Element
+}
+
+func newFIFOPolicy[K comparable]() *fifoPolicy[K] {
+	return &fifoPolicy[K]{l: list.New(), elem: make(map[K]*list.Element)}
+}
+
+func (p *fifoPolicy[K]) OnInsert(key K) {
+	p.elem[key] = p.l.PushFront(key)
+}
+
+func (p *fifoPolicy[K]) OnAccess(K) {}
+
+func (p *fifoPolicy[K]) Remove(key K) {
+	if e, ok := p.elem[key]; ok {
+		p.l.Remove(e)
+		delete(p.elem, key)
+	}
+}
+
+func (p *fifoPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n; i++ {
+		b := p.l.Back()
+		if b == nil {
+			break
+		}
+		key := b.Value.(K)
+		p.l.Remove(b)
+		delete(p.elem, key)
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// randomPolicy evicts a uniformly random key. keys holds every tracked key contiguously
+// so Evict can draw a random index in O(1); idx maps a key back to its slot so Remove can
+// delete it in O(1) by swapping in the last element, same trick sharded_lfu's callers
+// don't need but a plain slice-backed set otherwise would.
+type randomPolicy[K comparable] struct {
+	keys []K
+	idx  map[K]int
+}
+
+func newRandomPolicy[K comparable]() *randomPolicy[K] {
+	return &randomPolicy[K]{idx: make(map[K]int)}
+}
+
+func (p *randomPolicy[K]) OnInsert(key K) {
+	p.idx[key] = len(p.keys)
+	p.keys = append(p.keys, key)
+}
+
+func (p *randomPolicy[K]) OnAccess(K) {}
+
+func (p *randomPolicy[K]) Remove(key K) {
+	i, ok := p.idx[key]
+	if !ok {
+		return
+	}
+	last := len(p.keys) - 1
+	p.keys[i] = p.keys[last]
+	p.idx[p.keys[i]] = i
+	p.keys = p.keys[:last]
+	delete(p.idx, key)
+}
+
+func (p *randomPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n && len(p.keys) > 0; i++ {
+		key := p.keys[rand.Intn(len(p.keys))]
+		p.Remove(key)
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// lfuFreqNode is one bucket of lfuPolicy's frequency list: every key in list currently
+// has exactly freq accesses recorded.
+type lfuFreqNode[K comparable] struct {
+	freq uint
+	list *list.List
+}
+
+// lfuPolicy evicts the least-frequently-accessed key in O(1) per operation, using the
+// classic frequency-bucketed structure (as in Redis's LFU and the common "O(1) LFU
+// Cache" interview solution): each distinct frequency has its own doubly-linked list of
+// keys, a key moves from its current bucket to the freq+1 bucket on access, and eviction
+// always pops from the minimum-frequency bucket. This replaces LFUCache's O(n) move,
+// which walks the shared eviction list looking for where a key's new frequency belongs.
+type lfuPolicy[K comparable] struct {
+	freqOf  map[K]uint
+	elemOf  map[K]*list.Element
+	buckets map[uint]*lfuFreqNode[K]
+	minFreq uint
+	count   int
+}
+
+func newLFUPolicy[K comparable]() *lfuPolicy[K] {
+	return &lfuPolicy[K]{
+		freqOf:  make(map[K]uint),
+		elemOf:  make(map[K]*list.Element),
+		buckets: make(map[uint]*lfuFreqNode[K]),
+	}
+}
+
+func (p *lfuPolicy[K]) bucket(freq uint) *lfuFreqNode[K] {
+	b, ok := p.buckets[freq]
+	if !ok {
+		b = &lfuFreqNode[K]{freq: freq, list: list.New()}
+		p.buckets[freq] = b
+	}
+	return b
+}
+
+func (p *lfuPolicy[K]) OnInsert(key K) {
+	p.freqOf[key] = 1
+	p.elemOf[key] = p.bucket(1).list.PushFront(key)
+	p.minFreq = 1
+	p.count++
+}
+
+func (p *lfuPolicy[K]) OnAccess(key K) {
+	freq, ok := p.freqOf[key]
+	if !ok {
+		return
+	}
+	elem := p.elemOf[key]
+	oldBucket := p.buckets[freq]
+	oldBucket.list.Remove(elem)
+	if oldBucket.list.Len() == 0 {
+		delete(p.buckets, freq)
+		if p.minFreq == freq {
+			p.minFreq++
+		}
+	}
+	p.freqOf[key] = freq + 1
+	p.elemOf[key] = p.bucket(freq + 1).list.PushFront(key)
+}
+
+func (p *lfuPolicy[K]) Remove(key K) {
+	freq, ok := p.freqOf[key]
+	if !ok {
+		return
+	}
+	if b, ok := p.buckets[freq]; ok {
+		b.list.Remove(p.elemOf[key])
+		if b.list.Len() == 0 {
+			delete(p.buckets, freq)
+		}
+	}
+	delete(p.freqOf, key)
+	delete(p.elemOf, key)
+	p.count--
+}
+
+func (p *lfuPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n; i++ {
+		if p.count == 0 {
+			break
+		}
+		for {
+			b, ok := p.buckets[p.minFreq]
+			if ok && b.list.Len() > 0 {
+				break
+			}
+			p.minFreq++
+		}
+		b := p.buckets[p.minFreq]
+		back := b.list.Back()
+		key := back.Value.(K)
+		b.list.Remove(back)
+		if b.list.Len() == 0 {
+			delete(p.buckets, p.minFreq)
+		}
+		delete(p.freqOf, key)
+		delete(p.elemOf, key)
+		p.count--
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// arcPolicy implements Adaptive Replacement Cache: two LRU lists of keys actually held by
+// the cache, T1 (recently inserted, accessed once) and T2 (accessed more than once), each
+// paired with a ghost list of evicted keys, B1 and B2, that hold no values but let a
+// future re-insertion of a recently-evicted key teach the policy whether it should have
+// favored recency (a B1 hit) or frequency (a B2 hit) more. p is the target size of T1,
+// adapting by one of these signals on every ghost hit; REPLACE then evicts from T1 or T2
+// depending on which side of p is currently oversized.
+//
+// PolicyCache's contract (OnInsert for a logically new placement, Evict(n) only when
+// real capacity requires it) doesn't match the ARC paper's REPLACE exactly, which is
+// normally invoked inline during insertion using knowledge of whether the inserted key
+// was itself a ghost hit. arcPolicy bridges this by having OnInsert record whether the
+// key it just placed was a B1 or B2 ghost hit, and Evict's REPLACE step consume that
+// record. Since PolicyCache holds its lock across the OnInsert-then-Evict sequence for a
+// single Set, and weight 1 per entry (the common case) means at most one Evict(1) follows
+// each OnInsert, this reproduces the paper's per-insertion REPLACE call exactly; for a
+// heavier entry requiring multiple evictions, REPLACE is simply run once per evicted
+// entry instead of once per insertion, the same generalization evictToFit already applies
+// to every other policy's capacity handling.
+type arcPolicy[K comparable] struct {
+	c                  int
+	p                  int
+	t1, t2, b1, b2     *list.List
+	t1elem, t2elem     map[K]*list.Element
+	b1elem, b2elem     map[K]*list.Element
+	lastInsertGhostHit int // 0 = none, 1 = B1 hit, 2 = B2 hit; consumed by the next replace
+}
+
+func newARCPolicy[K comparable](capacity uint) *arcPolicy[K] {
+	c := int(capacity)
+	if c < 1 {
+		c = 1
+	}
+	return &arcPolicy[K]{
+		c:      c,
+		t1:     list.New(),
+		t2:     list.New(),
+		b1:     list.New(),
+		b2:     list.New(),
+		t1elem: make(map[K]*list.Element),
+		t2elem: make(map[K]*list.Element),
+		b1elem: make(map[K]*list.Element),
+		b2elem: make(map[K]*list.Element),
+	}
+}
+
+func arcMax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func arcMin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (a *arcPolicy[K]) trimGhost(l *list.List, elem map[K]*list.Element) {
+	for l.Len() > a.c {
+		back := l.Back()
+		key := back.Value.(K)
+		l.Remove(back)
+		delete(elem, key)
+	}
+}
+
+func (a *arcPolicy[K]) OnInsert(key K) {
+	a.lastInsertGhostHit = 0
+
+	if e, ok := a.b1elem[key]; ok {
+		delta := 1
+		if a.b2.Len() > 0 {
+			delta = arcMax(1, a.b1.Len()/a.b2.Len())
+		}
+		a.p = arcMin(a.c, a.p+delta)
+		a.b1.Remove(e)
+		delete(a.b1elem, key)
+		a.lastInsertGhostHit = 1
+		a.t2elem[key] = a.t2.PushFront(key)
+		return
+	}
+
+	if e, ok := a.b2elem[key]; ok {
+		delta := 1
+		if a.b1.Len() > 0 {
+			delta = arcMax(1, a.b2.Len()/a.b1.Len())
+		}
+		a.p = arcMax(0, a.p-delta)
+		a.b2.Remove(e)
+		delete(a.b2elem, key)
+		a.lastInsertGhostHit = 2
+		a.t2elem[key] = a.t2.PushFront(key)
+		return
+	}
+
+	a.t1elem[key] = a.t1.PushFront(key)
+}
+
+func (a *arcPolicy[K]) OnAccess(key K) {
+	if e, ok := a.t1elem[key]; ok {
+		a.t1.Remove(e)
+		delete(a.t1elem, key)
+		a.t2elem[key] = a.t2.PushFront(key)
+		return
+	}
+	if e, ok := a.t2elem[key]; ok {
+		a.t2.MoveToFront(e)
+	}
+}
+
+func (a *arcPolicy[K]) Remove(key K) {
+	if e, ok := a.t1elem[key]; ok {
+		a.t1.Remove(e)
+		delete(a.t1elem, key)
+		return
+	}
+	if e, ok := a.t2elem[key]; ok {
+		a.t2.Remove(e)
+		delete(a.t2elem, key)
+		return
+	}
+	if e, ok := a.b1elem[key]; ok {
+		a.b1.Remove(e)
+		delete(a.b1elem, key)
+		return
+	}
+	if e, ok := a.b2elem[key]; ok {
+		a.b2.Remove(e)
+		delete(a.b2elem, key)
+	}
+}
+
+// replace implements the ARC paper's REPLACE(x, p): evict from T1's LRU end if T1 is
+// oversized relative to p (or exactly at p following a B2 ghost hit), otherwise evict
+// from T2's LRU end, moving the evicted key onto the matching ghost list.
+func (a *arcPolicy[K]) replace() (K, bool) {
+	var zero K
+	ghostHit := a.lastInsertGhostHit
+	a.lastInsertGhostHit = 0
+
+	preferT1 := a.t1.Len() > 0 && (a.t1.Len() > a.p || (ghostHit == 2 && a.t1.Len() == a.p))
+	if preferT1 {
+		back := a.t1.Back()
+		key := back.Value.(K)
+		a.t1.Remove(back)
+		delete(a.t1elem, key)
+		a.b1elem[key] = a.b1.PushFront(key)
+		a.trimGhost(a.b1, a.b1elem)
+		return key, true
+	}
+
+	back := a.t2.Back()
+	if back == nil {
+		back = a.t1.Back()
+		if back == nil {
+			return zero, false
+		}
+		key := back.Value.(K)
+		a.t1.Remove(back)
+		delete(a.t1elem, key)
+		a.b1elem[key] = a.b1.PushFront(key)
+		a.trimGhost(a.b1, a.b1elem)
+		return key, true
+	}
+	key := back.Value.(K)
+	a.t2.Remove(back)
+	delete(a.t2elem, key)
+	a.b2elem[key] = a.b2.PushFront(key)
+	a.trimGhost(a.b2, a.b2elem)
+	return key, true
+}
+
+func (a *arcPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n; i++ {
+		key, ok := a.replace()
+		if !ok {
+			break
+		}
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// tlfuNode is one entry in tinyLFUPolicy's window/probation/protected lists: segment
+// records which of the three the node currently lives in, since all three share the same
+// node type and OnAccess/Evict need to tell them apart without a type switch on the list.
+type tlfuNode[K comparable] struct {
+	key     K
+	segment tinyLFUSegment
+}
+
+// tinyLFUPolicy implements W-TinyLFU (Ben Manes' design for Caffeine, the same scheme
+// TinyLFUCache hard-codes): a small LRU "window" admits new keys, and a segmented-LRU
+// "main" cache (split 20/80 between probation and protected) holds entries that have
+// proven themselves. When the window overflows, the evicted candidate is only admitted
+// into main if a Count-Min Sketch estimates it has been accessed more often than main's
+// own eviction victim, which is what lets TinyLFU resist scan-heavy workloads while
+// staying O(1) per operation.
+//
+// Unlike TinyLFUCache, which runs this admission contest inline as part of inserting a
+// new entry, tinyLFUPolicy defers it to Evict: PolicyCache only calls Evict once real
+// capacity requires removing something, so the window-vs-main contest runs there instead,
+// always producing exactly one evicted key per call (either the rejected candidate or the
+// replaced victim) to match the policy[K] contract every other implementation follows.
+type tinyLFUPolicy[K comparable] struct {
+	windowCap, mainCap, protectedCap      uint64
+	windowLen, probationLen, protectedLen uint64
+	elem                                  map[K]*list.Element
+	window, probation, protected          *list.List
+	sketch                                *countMinSketch
+}
+
+func newTinyLFUPolicy[K comparable](capacity uint) *tinyLFUPolicy[K] {
+	windowCap := uint64(capacity) / 100
+	if windowCap < 1 {
+		windowCap = 1
+	}
+	mainCap := uint64(capacity) - windowCap
+	protectedCap := mainCap * 8 / 10
+
+	return &tinyLFUPolicy[K]{
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: protectedCap,
+		elem:         make(map[K]*list.Element),
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+		sketch:       newCountMinSketch(capacity),
+	}
+}
+
+func (p *tinyLFUPolicy[K]) OnInsert(key K) {
+	node := &tlfuNode[K]{key: key, segment: segWindow}
+	p.elem[key] = p.window.PushFront(node)
+	p.windowLen++
+}
+
+func (p *tinyLFUPolicy[K]) OnAccess(key K) {
+	p.sketch.add(key)
+
+	e, ok := p.elem[key]
+	if !ok {
+		return
+	}
+	node := e.Value.(*tlfuNode[K])
+	switch node.segment {
+	case segWindow:
+		p.window.MoveToFront(e)
+	case segProtected:
+		p.protected.MoveToFront(e)
+	case segProbation:
+		p.probation.Remove(e)
+		p.probationLen--
+		node.segment = segProtected
+		p.elem[key] = p.protected.PushFront(node)
+		p.protectedLen++
+		p.demoteProtectedOverflow()
+	}
+}
+
+// demoteProtectedOverflow pushes protected's coldest entries back to probation until
+// protected is back within its capacity, mirroring TinyLFUCache.demoteProtectedOverflow.
+func (p *tinyLFUPolicy[K]) demoteProtectedOverflow() {
+	for p.protectedLen > p.protectedCap {
+		back := p.protected.Back()
+		if back == nil {
+			return
+		}
+		node := back.Value.(*tlfuNode[K])
+		p.protected.Remove(back)
+		p.protectedLen--
+		node.segment = segProbation
+		p.elem[node.key] = p.probation.PushFront(node)
+		p.probationLen++
+	}
+}
+
+func (p *tinyLFUPolicy[K]) Remove(key K) {
+	e, ok := p.elem[key]
+	if !ok {
+		return
+	}
+	node := e.Value.(*tlfuNode[K])
+	switch node.segment {
+	case segWindow:
+		p.window.Remove(e)
+		p.windowLen--
+	case segProbation:
+		p.probation.Remove(e)
+		p.probationLen--
+	case segProtected:
+		p.protected.Remove(e)
+		p.protectedLen--
+	}
+	delete(p.elem, key)
+}
+
+func (p *tinyLFUPolicy[K]) Evict(n int) []K {
+	evicted := make([]K, 0, n)
+	for i := 0; i < n; i++ {
+		key, ok := p.evictOne()
+		if !ok {
+			break
+		}
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// evictOne runs the window-admission contest until exactly one key is actually evicted:
+// an admitted candidate just moves segment (net zero removals) and the contest repeats,
+// so the loop always terminates with either a rejected candidate, a replaced main victim,
+// or (once the window is no longer over its budget) main's own coldest entry.
+func (p *tinyLFUPolicy[K]) evictOne() (K, bool) {
+	var zero K
+	for p.windowLen > p.windowCap {
+		back := p.window.Back()
+		if back == nil {
+			break
+		}
+		candidate := back.Value.(*tlfuNode[K])
+		p.window.Remove(back)
+		p.windowLen--
+		delete(p.elem, candidate.key)
+
+		if p.probationLen+p.protectedLen < p.mainCap {
+			candidate.segment = segProbation
+			p.elem[candidate.key] = p.probation.PushFront(candidate)
+			p.probationLen++
+			continue
+		}
+
+		victimElem := p.probation.Back()
+		if victimElem == nil {
+			victimElem = p.protected.Back()
+		}
+		if victimElem == nil {
+			candidate.segment = segProbation
+			p.elem[candidate.key] = p.probation.PushFront(candidate)
+			p.probationLen++
+			continue
+		}
+		victim := victimElem.Value.(*tlfuNode[K])
+
+		if p.sketch.estimate(candidate.key) <= p.sketch.estimate(victim.key) {
+			return candidate.key, true
+		}
+
+		switch victim.segment {
+		case segProbation:
+			p.probation.Remove(victimElem)
+			p.probationLen--
+		case segProtected:
+			p.protected.Remove(victimElem)
+			p.protectedLen--
+		}
+		delete(p.elem, victim.key)
+		candidate.segment = segProbation
+		p.elem[candidate.key] = p.probation.PushFront(candidate)
+		p.probationLen++
+		return victim.key, true
+	}
+
+	if e := p.probation.Back(); e != nil {
+		node := e.Value.(*tlfuNode[K])
+		p.probation.Remove(e)
+		p.probationLen--
+		delete(p.elem, node.key)
+		return node.key, true
+	}
+	if e := p.protected.Back(); e != nil {
+		node := e.Value.(*tlfuNode[K])
+		p.protected.Remove(e)
+		p.protectedLen--
+		delete(p.elem, node.key)
+		return node.key, true
+	}
+	if e := p.window.Back(); e != nil {
+		node := e.Value.(*tlfuNode[K])
+		p.window.Remove(e)
+		p.windowLen--
+		delete(p.elem, node.key)
+		return node.key, true
+	}
+	return zero, false
+}
@@ -0,0 +1,75 @@
+package incache
+
+import "testing"
+
+func TestLFUPolicy_EvictsLeastFrequentFirst(t *testing.T) {
+	p := newLFUPolicy[int]()
+
+	p.OnInsert(1)
+	p.OnInsert(2)
+	p.OnInsert(3)
+	p.OnAccess(1)
+	p.OnAccess(1)
+	p.OnAccess(2)
+	// frequencies: 1 -> 3, 2 -> 2, 3 -> 1
+
+	evicted := p.Evict(1)
+	if len(evicted) != 1 || evicted[0] != 3 {
+		t.Errorf("Evict(1) = %v, want [3]", evicted)
+	}
+
+	evicted = p.Evict(1)
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("Evict(1) = %v, want [2]", evicted)
+	}
+}
+
+func TestLFUPolicy_RemoveStopsTracking(t *testing.T) {
+	p := newLFUPolicy[int]()
+
+	p.OnInsert(1)
+	p.OnInsert(2)
+	p.Remove(1)
+
+	evicted := p.Evict(1)
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("Evict(1) = %v, want [2] after removing 1", evicted)
+	}
+	if len(p.Evict(1)) != 0 {
+		t.Errorf("expected no entries left to evict")
+	}
+}
+
+func TestARCPolicy_GhostHitPromotesRecency(t *testing.T) {
+	p := newARCPolicy[int](2)
+
+	p.OnInsert(1)
+	p.OnInsert(2)
+	p.Evict(1) // 1 is the T1 LRU entry, moves to B1
+
+	pBefore := p.p
+	p.OnInsert(1) // re-inserting a B1 ghost should grow p (favor recency)
+
+	if p.p <= pBefore {
+		t.Errorf("p = %d, want > %d after a B1 ghost hit", p.p, pBefore)
+	}
+	if _, ok := p.t2elem[1]; !ok {
+		t.Errorf("expected key 1 to be promoted straight to T2 on a ghost hit")
+	}
+}
+
+func TestARCPolicy_FrequentKeySurvivesOverRecentOneTimeKeys(t *testing.T) {
+	p := newARCPolicy[int](3)
+
+	p.OnInsert(1)
+	p.OnAccess(1) // promotes 1 into T2 (frequent)
+
+	p.OnInsert(2)
+	p.OnInsert(3)
+	p.OnInsert(4) // T1 now has 2,3,4 while T2 holds 1; capacity exceeded by one
+
+	evicted := p.Evict(1)
+	if len(evicted) != 1 || evicted[0] == 1 {
+		t.Errorf("Evict(1) = %v, did not expect the frequently accessed key 1 to be chosen", evicted)
+	}
+}
@@ -0,0 +1,172 @@
+package incache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestEvents_OnEviction_Expired and TestEvents_OnEviction_Purged round out the
+// eviction-reason coverage in events_test.go (which already exercises ReasonCapacity
+// and ReasonDeleted) so every EvictionReason a caller can observe is covered by a test.
+
+func TestEvents_OnEviction_Expired(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+		c.OnEviction(func(k int, v string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		c.SetWithTimeout(1, "one", time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		c.Get(1) // lazily triggers the expiration
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(reasons)
+			mu.Unlock()
+			if n >= 1 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(reasons) != 1 || reasons[0] != ReasonExpired {
+			t.Errorf("%s: expected one ReasonExpired eviction, got %v", et, reasons)
+		}
+	}
+}
+
+func TestEvents_OnEviction_Purged(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+		c.OnEviction(func(k int, v string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		c.Set(1, "one")
+		c.Set(2, "two")
+		c.Purge()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(reasons)
+			mu.Unlock()
+			if n >= 2 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(reasons) != 2 {
+			t.Errorf("%s: expected two ReasonPurged evictions, got %v", et, reasons)
+		}
+		for _, r := range reasons {
+			if r != ReasonPurged {
+				t.Errorf("%s: expected ReasonPurged, got %v", et, r)
+			}
+		}
+	}
+}
+
+func TestEvents_OnEviction_Transferred(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		src := New[int, string](10)
+		src.EvictType(et)
+		srcCache := src.Build()
+
+		dst := New[int, string](10)
+		dst.EvictType(et)
+		dstCache := dst.Build()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+		srcCache.OnEviction(func(k int, v string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		srcCache.Set(1, "one")
+		srcCache.Set(2, "two")
+		srcCache.TransferTo(dstCache)
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(reasons)
+			mu.Unlock()
+			if n >= 2 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(reasons) != 2 {
+			t.Errorf("%s: expected two ReasonTransferred evictions, got %v", et, reasons)
+		}
+		for _, r := range reasons {
+			if r != ReasonTransferred {
+				t.Errorf("%s: expected ReasonTransferred, got %v", et, r)
+			}
+		}
+
+		if got := srcCache.Used(); got != 0 {
+			t.Errorf("%s: expected source cache's Used() to be 0 after TransferTo, got %d", et, got)
+		}
+		if v, ok := dstCache.Get(1); !ok || v != "one" {
+			t.Errorf("%s: expected key1 to have arrived at the destination cache", et)
+		}
+	}
+}
+
+// TestEvents_CallbackCanReenterCache proves that OnEviction/OnInsertion run off the
+// cache's internal lock: a callback that calls back into the same cache must not
+// deadlock, since eventDispatcher delivers events from its own goroutine.
+func TestEvents_CallbackCanReenterCache(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		done := make(chan struct{})
+		c.OnInsertion(func(k int, v string) {
+			if k == 1 {
+				c.Set(2, "reentrant")
+			}
+			if k == 2 {
+				close(done)
+			}
+		})
+
+		c.Set(1, "one")
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("%s: callback re-entering the cache deadlocked", et)
+		}
+	}
+}
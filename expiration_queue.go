@@ -0,0 +1,118 @@
+package incache
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expQueueItem is one entry in an expirationQueue: a key and the absolute time it expires
+// at. heapIndex is maintained by container/heap's Swap so remove/push can locate an
+// existing item in O(1) instead of scanning the heap.
+type expQueueItem[K comparable] struct {
+	key       K
+	expireAt  time.Time
+	heapIndex int
+}
+
+// expQueueHeap is the container/heap.Interface implementation backing expirationQueue.
+// It is unexported: callers use expirationQueue's push/remove/peek/pop instead of the
+// heap package directly, so the index map stays in sync with every mutation.
+type expQueueHeap[K comparable] []*expQueueItem[K]
+
+func (h expQueueHeap[K]) Len() int { return len(h) }
+
+func (h expQueueHeap[K]) Less(i, j int) bool { return h[i].expireAt.Before(h[j].expireAt) }
+
+func (h expQueueHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *expQueueHeap[K]) Push(x any) {
+	item := x.(*expQueueItem[K])
+	item.heapIndex = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *expQueueHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// expirationQueue is a binary min-heap of (key, expiry) pairs, giving a cache O(log n)
+// insert, update, and removal of expiration bookkeeping, and O(1) access to the
+// soonest-expiring key. It is the single-timer replacement for periodically scanning the
+// whole cache for expired entries: the janitor, in SweepHeap mode, sleeps until
+// expirationQueue's earliest deadline instead of waking up on a fixed interval to check
+// every entry. Callers (the owning cache) are responsible for their own locking;
+// expirationQueue itself is not safe for concurrent use.
+type expirationQueue[K comparable] struct {
+	h     expQueueHeap[K]
+	index map[K]*expQueueItem[K]
+}
+
+func newExpirationQueue[K comparable]() *expirationQueue[K] {
+	return &expirationQueue[K]{index: make(map[K]*expQueueItem[K])}
+}
+
+// push adds key with the given expiry, or updates its expiry if key is already queued,
+// restoring the heap invariant in either case. A nil q (SweepHeap not configured) is a
+// no-op, mirroring negativeCache's nil-receiver pattern for an optional feature.
+func (q *expirationQueue[K]) push(key K, expireAt time.Time) {
+	if q == nil {
+		return
+	}
+	if item, ok := q.index[key]; ok {
+		item.expireAt = expireAt
+		heap.Fix(&q.h, item.heapIndex)
+		return
+	}
+	item := &expQueueItem[K]{key: key, expireAt: expireAt}
+	q.index[key] = item
+	heap.Push(&q.h, item)
+}
+
+// remove drops key from the queue, if present. A no-op otherwise, including on a nil q.
+func (q *expirationQueue[K]) remove(key K) {
+	if q == nil {
+		return
+	}
+	item, ok := q.index[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&q.h, item.heapIndex)
+	delete(q.index, key)
+}
+
+// peek returns the soonest-expiring key and its expiry time, without removing it.
+func (q *expirationQueue[K]) peek() (K, time.Time, bool) {
+	if q == nil || len(q.h) == 0 {
+		var zero K
+		return zero, time.Time{}, false
+	}
+	top := q.h[0]
+	return top.key, top.expireAt, true
+}
+
+// pop removes and discards the soonest-expiring entry. Callers use peek to decide whether
+// an entry is actually due before popping it.
+func (q *expirationQueue[K]) pop() {
+	if q == nil || len(q.h) == 0 {
+		return
+	}
+	item := heap.Pop(&q.h).(*expQueueItem[K])
+	delete(q.index, item.key)
+}
+
+func (q *expirationQueue[K]) len() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.h)
+}
@@ -0,0 +1,121 @@
+package incache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSet_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.Set("key1", "value1")
+	if c.m["key1"].Value.(*fifoItem[string, string]).value != "value1" {
+		t.Errorf("Set failed")
+	}
+}
+
+func TestGet_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.Set("key1", "value1")
+	if v, ok := c.Get("key1"); !ok || v != "value1" {
+		t.Errorf("Get failed")
+	}
+}
+
+func TestSetWithTimeout_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.SetWithTimeout("key1", "value1", time.Millisecond)
+
+	time.Sleep(time.Millisecond)
+
+	if c.m["key1"].Value.(*fifoItem[string, string]).value != "value1" {
+		t.Errorf("SetWithTimeout failed")
+	}
+}
+
+func TestNotFoundSet_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	if !c.NotFoundSet("key1", "value1") {
+		t.Errorf("NotFoundSet failed")
+	}
+
+	if c.NotFoundSet("key1", "value2") {
+		t.Errorf("NotFoundSet failed")
+	}
+}
+
+func TestDelete_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Delete("key1")
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("Delete failed")
+	}
+}
+
+func TestPurge_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Set("key2", "value2")
+
+	c.Purge()
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("Purge failed")
+	}
+}
+
+// TestEvictionOrder_FIFO asserts the defining FIFO property: eviction order follows
+// insertion order only, so reading key1 (as an LRU would promote) must not save it from
+// being the first one evicted once the cache is over capacity.
+func TestEvictionOrder_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](3)
+
+	c.Set("key1", "value1")
+	c.Set("key2", "value2")
+	c.Set("key3", "value3")
+
+	// Repeatedly reading key1 would keep it alive in an LRUCache, but must not here.
+	for i := 0; i < 5; i++ {
+		c.Get("key1")
+	}
+
+	c.Set("key4", "value4")
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("expected key1 to be evicted first despite being recently read")
+	}
+	if _, ok := c.Get("key4"); !ok {
+		t.Errorf("expected key4 to still be present")
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len: expected: %d, got: %d", 3, c.Len())
+	}
+}
+
+func TestCount_FIFO(t *testing.T) {
+	c := NewFIFO[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Set("key2", "value2")
+
+	if c.Count() != 2 {
+		t.Errorf("Count failed")
+	}
+}
+
+func TestBuild_FIFO(t *testing.T) {
+	b := New[string, string](10)
+	b.EvictType(FIFO)
+	c := b.Build()
+
+	if _, ok := c.(*FIFOCache[string, string]); !ok {
+		t.Errorf("expected Build with EvictType(FIFO) to return a *FIFOCache")
+	}
+}
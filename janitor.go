@@ -0,0 +1,192 @@
+package incache
+
+import (
+	"sync"
+	"time"
+)
+
+// SweepStrategy selects how a cache's background janitor looks for expired entries.
+type SweepStrategy int
+
+const (
+	// SweepFull scans every entry on each tick. It is thorough, but its per-tick cost
+	// is O(n), so on a large cache with mostly live keys it can pause the cache for the
+	// duration of the scan; sweepFull implementations mitigate this by processing the
+	// scan in bounded batches and releasing the write lock between them.
+	SweepFull SweepStrategy = iota
+
+	// SweepSampled follows Redis's approach to lazy expiration: each tick draws a random
+	// sample of keys, removes the ones that have expired, and immediately resamples if
+	// the expired ratio was high (up to a bounded number of iterations), instead of
+	// scanning the whole cache. This trades sweep thoroughness for a bounded per-tick
+	// cost, which matters on large caches where most keys are still live.
+	SweepSampled
+
+	// SweepHeap replaces periodic scanning entirely with an expirationQueue min-heap and
+	// a single timer: every entry with a TTL is tracked in the heap in O(log n), the
+	// janitor sleeps exactly until the soonest-expiring entry is due instead of waking up
+	// on a fixed interval, and expiring that entry is an O(log n) heap pop rather than an
+	// O(n) or O(sample size) scan. It is only honored by cache implementations that
+	// support heap-driven expiry (see expiryHeapSweeper); others ignore it.
+	SweepHeap
+)
+
+const (
+	// sweepSampleSize is the number of keys inspected per SweepSampled iteration.
+	sweepSampleSize = 20
+	// sweepSampledExpiredRatio is the expired fraction above which SweepSampled
+	// immediately resamples instead of waiting for the next tick.
+	sweepSampledExpiredRatio = 0.25
+	// sweepSampledMaxIterations bounds how many times SweepSampled resamples per tick,
+	// so a cache that is mostly expired entries can't spin the janitor forever.
+	sweepSampledMaxIterations = 10
+	// sweepFullBatchSize bounds how many entries SweepFull inspects while holding the
+	// write lock before yielding it, so a full sweep of a large cache never becomes a
+	// single stop-the-world pause.
+	sweepFullBatchSize = 256
+)
+
+// expirySweeper is implemented by caches that a janitor can sweep for expired entries.
+// Both methods are safe for concurrent use and take care of their own locking, since the
+// janitor calls them from its own background goroutine.
+type expirySweeper interface {
+	// sweepFull removes every currently expired entry, processing at most batchSize
+	// entries per lock acquisition so the sweep never holds the write lock for the
+	// whole cache.
+	sweepFull(batchSize int)
+
+	// sweepSampled inspects up to n entries and removes the ones that have expired,
+	// reporting how many entries it looked at and how many it removed.
+	sweepSampled(n int) (sampled, expired int)
+}
+
+// expiryHeapSweeper is implemented by caches built with SweepHeap. Instead of the janitor
+// waking up on a fixed interval, it asks the cache when its soonest entry expires and
+// sleeps exactly until then.
+type expiryHeapSweeper interface {
+	// nextExpiry returns the expiry time of the soonest-expiring entry still tracked in
+	// the cache's expirationQueue, and false if nothing with a TTL is queued.
+	nextExpiry() (time.Time, bool)
+
+	// sweepDue removes every entry whose expiry has already passed.
+	sweepDue()
+}
+
+// janitor periodically sweeps a cache for expired entries on a background goroutine. It
+// is shared across every EvictType, configured via CacheBuilder.Janitor, so the sweep
+// loop and stop semantics are implemented once. In SweepHeap mode it instead drives off
+// an expirationQueue's single timer; see runHeap.
+type janitor struct {
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wakeCh   chan struct{} // heap mode only: non-nil, signals "re-check the next deadline"
+}
+
+// startJanitor launches a janitor that sweeps target every interval according to
+// strategy. It returns nil if interval is not positive, leaving the cache to expire
+// entries lazily on Get and opportunistically during capacity eviction, as it does when
+// no Janitor is configured at all. For SweepHeap, it returns nil if target doesn't
+// implement expiryHeapSweeper.
+func startJanitor(interval time.Duration, strategy SweepStrategy, target expirySweeper) *janitor {
+	if interval <= 0 {
+		return nil
+	}
+	if strategy == SweepHeap {
+		heapTarget, ok := target.(expiryHeapSweeper)
+		if !ok {
+			return nil
+		}
+		j := &janitor{stopCh: make(chan struct{}), wakeCh: make(chan struct{}, 1)}
+		go j.runHeap(heapTarget)
+		return j
+	}
+	j := &janitor{stopCh: make(chan struct{})}
+	go j.run(interval, strategy, target)
+	return j
+}
+
+func (j *janitor) run(interval time.Duration, strategy SweepStrategy, target expirySweeper) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if strategy == SweepSampled {
+				for i := 0; i < sweepSampledMaxIterations; i++ {
+					sampled, expired := target.sweepSampled(sweepSampleSize)
+					if sampled == 0 || float64(expired)/float64(sampled) < sweepSampledExpiredRatio {
+						break
+					}
+				}
+			} else {
+				target.sweepFull(sweepFullBatchSize)
+			}
+		case <-j.stopCh:
+			return
+		}
+	}
+}
+
+// heapIdleSleep is how long runHeap sleeps when the queue is empty, since there is no
+// deadline to wake it up early; wake unblocks it immediately once something is pushed.
+const heapIdleSleep = time.Hour
+
+// runHeap drives SweepHeap mode: it keeps a single timer set to target's soonest expiry,
+// firing sweepDue exactly when that entry is due, and resets the timer whenever wake is
+// signaled (a Set/SetWithTimeout pushed a new, possibly sooner, deadline onto the queue).
+func (j *janitor) runHeap(target expiryHeapSweeper) {
+	timer := time.NewTimer(heapIdleSleep)
+	defer timer.Stop()
+
+	resetTimer := func() {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		d := heapIdleSleep
+		if next, ok := target.nextExpiry(); ok {
+			if until := time.Until(next); until > 0 {
+				d = until
+			} else {
+				d = 0
+			}
+		}
+		timer.Reset(d)
+	}
+	resetTimer()
+
+	for {
+		select {
+		case <-timer.C:
+			target.sweepDue()
+			resetTimer()
+		case <-j.wakeCh:
+			resetTimer()
+		case <-j.stopCh:
+			return
+		}
+	}
+}
+
+// wake signals a SweepHeap janitor to recompute its sleep deadline, because a push may
+// have just queued a sooner expiry than the one it was already waiting on. It is a no-op
+// on a nil janitor or one not running in heap mode.
+func (j *janitor) wake() {
+	if j == nil || j.wakeCh == nil {
+		return
+	}
+	select {
+	case j.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop signals the janitor's goroutine to exit. Safe to call more than once, and from
+// multiple goroutines, since newManual's finalizer may race an explicit Purge.
+func (j *janitor) stop() {
+	j.stopOnce.Do(func() {
+		close(j.stopCh)
+	})
+}
@@ -0,0 +1,119 @@
+package incache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJanitor_SweepFullRemovesExpiredEntries(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](100)
+		b.EvictType(et)
+		b.Janitor(10*time.Millisecond, SweepFull)
+		c := b.Build()
+		defer c.Purge()
+
+		for i := 0; i < 50; i++ {
+			c.SetWithTimeout(i, "v", time.Millisecond)
+		}
+		c.Set(1000, "still alive")
+
+		deadline := time.Now().Add(time.Second)
+		for c.Len() > 1 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if l := c.Len(); l != 1 {
+			t.Errorf("%s: Len() = %d, want 1 after janitor sweep", et, l)
+		}
+		if _, ok := c.Get(1000); !ok {
+			t.Errorf("%s: expected non-expired entry to survive the sweep", et)
+		}
+	}
+}
+
+func TestJanitor_SweepSampledRemovesExpiredEntries(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](200)
+		b.EvictType(et)
+		b.Janitor(10*time.Millisecond, SweepSampled)
+		c := b.Build()
+		defer c.Purge()
+
+		for i := 0; i < 100; i++ {
+			c.SetWithTimeout(i, "v", time.Millisecond)
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for c.Len() > 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if l := c.Len(); l != 0 {
+			t.Errorf("%s: Len() = %d, want 0 after sampled sweeps", et, l)
+		}
+	}
+}
+
+func TestJanitor_SweepHeapRemovesExpiredEntries(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO, TinyLFU} {
+		b := New[int, string](100)
+		b.EvictType(et)
+		// The interval is irrelevant in SweepHeap mode: the janitor sleeps until the
+		// soonest tracked expiry instead of waking up on a fixed tick.
+		b.Janitor(time.Hour, SweepHeap)
+		c := b.Build()
+		defer c.Purge()
+
+		for i := 0; i < 50; i++ {
+			c.SetWithTimeout(i, "v", 10*time.Millisecond)
+		}
+		c.Set(1000, "still alive")
+
+		deadline := time.Now().Add(time.Second)
+		for c.Len() > 1 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		if l := c.Len(); l != 1 {
+			t.Errorf("%s: Len() = %d, want 1 after heap-driven sweep", et, l)
+		}
+		if _, ok := c.Get(1000); !ok {
+			t.Errorf("%s: expected non-expired entry to survive the sweep", et)
+		}
+	}
+}
+
+func TestJanitor_PurgeStopsGoroutine(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		b.Janitor(time.Millisecond, SweepFull)
+		c := b.Build()
+
+		c.Set(1, "one")
+		c.Purge()
+
+		// Give any in-flight tick a chance to run; the janitor goroutine must have
+		// exited, so nothing should touch the cache after Purge returns.
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestJanitor_NoJanitorWithZeroInterval(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.SetWithTimeout(1, "one", time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		// No janitor configured: the expired entry lingers in the backing store until
+		// something accesses it, so Len (which doesn't filter expired entries) still
+		// reports it, while Count (which does) does not.
+		if n := c.Count(); n != 0 {
+			t.Errorf("%s: Count() = %d, want 0", et, n)
+		}
+	}
+}
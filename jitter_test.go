@@ -0,0 +1,53 @@
+package incache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyJitter_NoFractionReturnsUnchanged(t *testing.T) {
+	if d := applyJitter(time.Minute, 0); d != time.Minute {
+		t.Errorf("expected %v unchanged, got %v", time.Minute, d)
+	}
+}
+
+func TestApplyJitter_StaysWithinBounds(t *testing.T) {
+	d := time.Minute
+	lo := time.Duration(float64(d) * 0.9)
+	hi := time.Duration(float64(d) * 1.1)
+
+	for i := 0; i < 1000; i++ {
+		got := applyJitter(d, 0.1)
+		if got < lo || got > hi {
+			t.Fatalf("applyJitter(%v, 0.1) = %v, want within [%v, %v]", d, got, lo, hi)
+		}
+	}
+}
+
+func TestWithExpiryJitter_PerturbsTTLAcrossManualAndLRU(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU} {
+		b := New[int, string](1000)
+		b.EvictType(et)
+		b.WithExpiryJitter(0.5)
+		c := b.Build()
+
+		for i := 0; i < 100; i++ {
+			c.SetWithTimeout(i, "v", 100*time.Millisecond)
+		}
+
+		// With +/-50% jitter on a 100ms TTL, entries should expire across a spread of
+		// at least a few milliseconds rather than all in the same instant.
+		time.Sleep(60 * time.Millisecond)
+		afterShortWait := c.Count()
+
+		time.Sleep(90 * time.Millisecond)
+		afterLongWait := c.Count()
+
+		if afterShortWait == 0 {
+			t.Errorf("%s: expected some entries to still be alive after 60ms given jitter spreads TTLs up to 150ms", et)
+		}
+		if afterLongWait != 0 {
+			t.Errorf("%s: expected all entries to have expired after 150ms total, got %d remaining", et, afterLongWait)
+		}
+	}
+}
@@ -2,6 +2,11 @@ package incache
 
 import (
 	"container/list"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 )
@@ -12,6 +17,19 @@ type LFUCache[K comparable, V any] struct {
 	size         uint
 	m            map[K]*list.Element
 	evictionList *list.List
+	loader       func(K) (V, time.Duration, error)
+	loaderGrp    loaderGroup[K, V]
+	events       *eventDispatcher[K, V]
+	weigher      func(K, V) uint64
+	usedCapacity uint64
+	stats        statsCounters
+	janitor      *janitor
+	persistor    *persistor
+	negCache     *negativeCache[K]
+	expQueue     *expirationQueue[K]
+	maxBytes     uint64
+	sizer        func(V) int64
+	bytesUsed    uint64
 }
 
 func NewLFU[K comparable, V any](size uint) *LFUCache[K, V] {
@@ -19,6 +37,75 @@ func NewLFU[K comparable, V any](size uint) *LFUCache[K, V] {
 		size:         size,
 		m:            make(map[K]*list.Element),
 		evictionList: list.New(),
+		weigher:      defaultWeigher[K, V],
+	}
+}
+
+// newLFU builds an LFUCache from a CacheBuilder, letting it be produced via New[K,V](size).EvictType(LFU).Build().
+func newLFU[K comparable, V any](cacheBuilder *CacheBuilder[K, V]) *LFUCache[K, V] {
+	c := NewLFU[K, V](cacheBuilder.size)
+	c.loader = cacheBuilder.loader
+	c.weigher = resolveWeigher[K, V](cacheBuilder.weigher)
+	c.maxBytes = cacheBuilder.maxBytes
+	c.sizer = cacheBuilder.sizer
+	c.negCache = newNegativeCache[K](cacheBuilder.negativeTTL)
+	if cacheBuilder.janitorStrategy == SweepHeap {
+		c.expQueue = newExpirationQueue[K]()
+	}
+	c.janitor = startJanitor(cacheBuilder.janitorInterval, cacheBuilder.janitorStrategy, c)
+	if cacheBuilder.onInsert != nil || cacheBuilder.onEvict != nil {
+		c.events = newEventDispatcher[K, V]()
+		if cacheBuilder.onInsert != nil {
+			c.events.onInsertion(cacheBuilder.onInsert)
+		}
+		if cacheBuilder.onEvict != nil {
+			c.events.onEviction(cacheBuilder.onEvict)
+		}
+	}
+	if cacheBuilder.persistPath != "" {
+		_ = c.LoadFromFile(cacheBuilder.persistPath)
+		c.persistor = startPersistor(cacheBuilder.persistInterval, cacheBuilder.persistPath, c)
+	}
+	return c
+}
+
+// OnInsertion registers fn to be called, on a background goroutine, whenever a key-value
+// pair is inserted into the cache. It returns a listener id usable with RemoveInsertionListener.
+func (l *LFUCache[K, V]) OnInsertion(fn func(K, V)) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events == nil {
+		l.events = newEventDispatcher[K, V]()
+	}
+	return l.events.onInsertion(fn)
+}
+
+// RemoveInsertionListener unregisters a listener previously added with OnInsertion.
+func (l *LFUCache[K, V]) RemoveInsertionListener(id int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events != nil {
+		l.events.removeInsertionListener(id)
+	}
+}
+
+// OnEviction registers fn to be called, on a background goroutine, whenever a key-value
+// pair leaves the cache. It returns a listener id usable with RemoveEvictionListener.
+func (l *LFUCache[K, V]) OnEviction(fn func(K, V, EvictionReason)) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events == nil {
+		l.events = newEventDispatcher[K, V]()
+	}
+	return l.events.onEviction(fn)
+}
+
+// RemoveEvictionListener unregisters a listener previously added with OnEviction.
+func (l *LFUCache[K, V]) RemoveEvictionListener(id int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events != nil {
+		l.events.removeEvictionListener(id)
 	}
 }
 
@@ -27,6 +114,133 @@ type lfuItem[K comparable, V any] struct {
 	value    V
 	freq     uint
 	expireAt *time.Time
+	weight   uint64
+}
+
+// sizeOf returns value's byte size via the configured Sizer, falling back to Sizeable, or
+// 0 if neither is configured/implemented. A negative Sizer result is treated as 0.
+func (l *LFUCache[K, V]) sizeOf(value V) uint64 {
+	if l.sizer != nil {
+		if n := l.sizer(value); n > 0 {
+			return uint64(n)
+		}
+		return 0
+	}
+	if s, ok := any(value).(Sizeable); ok {
+		if n := s.Size(); n > 0 {
+			return uint64(n)
+		}
+	}
+	return 0
+}
+
+// evictToFit evicts entries until adding weight more would not exceed the cache's entry
+// capacity and adding bytes more would not exceed its configured byte capacity (if any),
+// or the cache is empty. Callers must hold l.mu.
+func (l *LFUCache[K, V]) evictToFit(weight, bytes uint64) {
+	for l.evictionList.Len() > 0 && (l.usedCapacity+weight > uint64(l.size) ||
+		(l.maxBytes > 0 && l.bytesUsed+bytes > l.maxBytes)) {
+		l.evict(1)
+	}
+}
+
+// expireIfPast removes key if it has expired, firing a ReasonExpired eviction and
+// recording it in stats. Callers must hold l.mu.
+func (l *LFUCache[K, V]) expireIfPast(key K) bool {
+	elem, ok := l.m[key]
+	if !ok {
+		return false
+	}
+	item := elem.Value.(*lfuItem[K, V])
+	if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+		return false
+	}
+	l.delete(key, elem)
+	l.usedCapacity -= item.weight
+	l.bytesUsed -= l.sizeOf(item.value)
+	l.expQueue.remove(key)
+	l.stats.recordEviction(ReasonExpired)
+	if l.events != nil {
+		l.events.fireEviction(key, item.value, ReasonExpired)
+	}
+	return true
+}
+
+// nextExpiry returns the expiry time of the soonest-expiring entry still tracked in the
+// cache's expirationQueue, for janitor.runHeap. Only meaningful when built with SweepHeap.
+func (l *LFUCache[K, V]) nextExpiry() (time.Time, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, expireAt, ok := l.expQueue.peek()
+	return expireAt, ok
+}
+
+// sweepDue removes every entry whose expiry has already passed, for janitor.runHeap.
+func (l *LFUCache[K, V]) sweepDue() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for {
+		key, expireAt, ok := l.expQueue.peek()
+		if !ok || expireAt.After(now) {
+			return
+		}
+		l.expQueue.pop()
+		elem, ok := l.m[key]
+		if !ok {
+			continue
+		}
+		item := elem.Value.(*lfuItem[K, V])
+		l.delete(key, elem)
+		l.usedCapacity -= item.weight
+		l.bytesUsed -= l.sizeOf(item.value)
+		l.stats.recordEviction(ReasonExpired)
+		if l.events != nil {
+			l.events.fireEviction(key, item.value, ReasonExpired)
+		}
+	}
+}
+
+// sweepFull removes every currently expired entry, processing at most batchSize entries
+// per lock acquisition so the janitor never holds the write lock for the whole cache.
+func (l *LFUCache[K, V]) sweepFull(batchSize int) {
+	l.mu.RLock()
+	keys := make([]K, 0, len(l.m))
+	for k := range l.m {
+		keys = append(keys, k)
+	}
+	l.mu.RUnlock()
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		l.mu.Lock()
+		for _, k := range keys[start:end] {
+			l.expireIfPast(k)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// sweepSampled inspects up to n entries, chosen via Go's randomized map iteration order,
+// and removes the ones that have expired.
+func (l *LFUCache[K, V]) sweepSampled(n int) (sampled, expired int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for k := range l.m {
+		if sampled >= n {
+			break
+		}
+		sampled++
+		if l.expireIfPast(k) {
+			expired++
+		}
+	}
+	return
 }
 
 // Set adds the key-value pair to the cache.
@@ -46,35 +260,98 @@ func (l *LFUCache[K, V]) SetWithTimeout(key K, value V, exp time.Duration) {
 }
 
 func (l *LFUCache[K, V]) set(key K, value V, exp time.Duration) {
-	item, ok := l.m[key]
+	l.loaderGrp.bump(key)
 	var tm *time.Time
 	if exp > 0 {
 		t := time.Now().Add(exp)
 		tm = &t
 	}
-	if ok {
-		lfuItem := item.Value.(*lfuItem[K, V])
+	weight := l.weigher(key, value)
+	bytes := l.sizeOf(value)
+
+	var freq uint = 1
+	if item, ok := l.m[key]; ok {
+		old := item.Value.(*lfuItem[K, V])
+		freq = old.freq + 1
+		l.evictionList.Remove(item)
+		delete(l.m, key)
+		l.usedCapacity -= old.weight
+		l.bytesUsed -= l.sizeOf(old.value)
+		l.stats.recordEviction(ReasonDeleted)
+		if l.events != nil {
+			l.events.fireEviction(key, old.value, ReasonDeleted)
+		}
+	}
 
-		lfuItem.value = value
-		lfuItem.expireAt = tm
-		lfuItem.freq++
+	l.evictToFit(weight, bytes)
 
-		l.move(item)
+	lfuItem := &lfuItem[K, V]{
+		key:      key,
+		value:    value,
+		expireAt: tm,
+		freq:     freq,
+		weight:   weight,
+	}
+	l.m[key] = l.evictionList.PushBack(lfuItem)
+	l.usedCapacity += weight
+	l.bytesUsed += bytes
+	l.move(l.m[key])
+	l.stats.insertions.Add(1)
+	if tm != nil {
+		l.expQueue.push(key, *tm)
+		l.janitor.wake()
 	} else {
-		if len(l.m) == int(l.size) {
-			l.evict(1)
-		}
+		l.expQueue.remove(key)
+	}
 
-		lfuItem := lfuItem[K, V]{
-			key:      key,
-			value:    value,
-			expireAt: tm,
-			freq:     1,
-		}
+	if l.events != nil {
+		l.events.fireInsertion(key, value)
+	}
+}
 
-		l.m[key] = l.evictionList.PushBack(&lfuItem)
-		l.move(l.m[key])
+// TrySet adds or updates the key-value pair in the cache, like Set, but rejects the entry
+// with ErrWeightExceedsCapacity if its weight alone exceeds the cache's capacity.
+func (l *LFUCache[K, V]) TrySet(key K, value V) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.weigher(key, value) > uint64(l.size) {
+		return ErrWeightExceedsCapacity
 	}
+
+	l.set(key, value, 0)
+	return nil
+}
+
+// Used returns the total weight of all entries currently stored in the cache.
+func (l *LFUCache[K, V]) Used() uint64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.usedCapacity
+}
+
+// Capacity returns the cache's capacity in weight units, as configured by New.
+func (l *LFUCache[K, V]) Capacity() uint64 {
+	return uint64(l.size)
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction/load counters.
+func (l *LFUCache[K, V]) Stats() Stats {
+	return l.stats.snapshot()
+}
+
+// MemoryStats returns the cache's current entry count and byte usage alongside its configured
+// entry and byte capacities. capBytes is 0 if WithMaxBytes was not configured, in which case
+// bytes reflects only whatever Sizer or Sizeable values happened to report, unenforced.
+func (l *LFUCache[K, V]) MemoryStats() (length int, bytes uint64, capEntries uint64, capBytes uint64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.m), l.bytesUsed, uint64(l.size), l.maxBytes
+}
+
+// ResetStats zeroes the cache's hit/miss/eviction/load counters.
+func (l *LFUCache[K, V]) ResetStats() {
+	l.stats.reset()
 }
 
 // Get retrieves the value associated with the given key from the cache.
@@ -86,21 +363,76 @@ func (l *LFUCache[K, V]) Get(key K) (v V, b bool) {
 
 	item, ok := l.m[key]
 	if !ok {
+		l.stats.misses.Add(1)
 		return
 	}
 
 	lfuItem := item.Value.(*lfuItem[K, V])
 	if lfuItem.expireAt != nil && lfuItem.expireAt.Before(time.Now()) {
 		l.delete(key, item)
+		l.usedCapacity -= lfuItem.weight
+		l.bytesUsed -= l.sizeOf(lfuItem.value)
+		l.expQueue.remove(key)
+		l.stats.misses.Add(1)
+		l.stats.recordEviction(ReasonExpired)
+		if l.events != nil {
+			l.events.fireEviction(key, lfuItem.value, ReasonExpired)
+		}
 		return
 	}
 
 	lfuItem.freq++
 	l.move(item)
+	l.stats.hits.Add(1)
 
 	return lfuItem.value, true
 }
 
+// GetOrLoad retrieves the value associated with the given key, invoking the configured
+// loader on a miss. Concurrent calls for the same key coalesce into a single loader invocation.
+// It returns ErrNoLoader if no loader was configured via CacheBuilder.Loader.
+func (l *LFUCache[K, V]) GetOrLoad(k K) (V, error) {
+	if v, ok := l.Get(k); ok {
+		return v, nil
+	}
+	if err, ok := l.negCache.get(k); ok {
+		var zero V
+		return zero, err
+	}
+	if l.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	var ttl time.Duration
+	var loaded bool
+	return l.loaderGrp.do(k, func() (V, error) {
+		if v, ok := l.Get(k); ok {
+			return v, nil
+		}
+		if err, ok := l.negCache.get(k); ok {
+			var zero V
+			return zero, err
+		}
+		start := time.Now()
+		v, d, err := l.loader(k)
+		l.stats.recordLoad(time.Since(start), err)
+		ttl = d
+		loaded = true
+		return v, err
+	}, func(v V, err error, fresh bool) {
+		if !loaded || !fresh {
+			return
+		}
+		if err != nil {
+			l.negCache.set(k, err)
+			return
+		}
+		l.negCache.clear(k)
+		l.SetWithTimeout(k, v, ttl)
+	})
+}
+
 // NotFoundSet adds the key-value pair to the cache only if the key does not exist.
 // It returns true if the key was added to the cache, otherwise false.
 func (l *LFUCache[K, V]) NotFoundSet(k K, v V) bool {
@@ -148,31 +480,83 @@ func (l *LFUCache[K, V]) GetAll() map[K]V {
 	return m
 }
 
-// TransferTo transfers all non-expired key-value pairs from the source cache to the destination cache.
-func (src *LFUCache[K, V]) TransferTo(dst *LFUCache[K, V]) {
+// TransferTo transfers all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *LFUCache[K, V]) TransferTo(dst Cache[K, V]) {
 	src.mu.Lock()
 	defer src.mu.Unlock()
 
 	for k, v := range src.m {
-		if v.Value.(*lfuItem[K, V]).expireAt == nil || !v.Value.(*lfuItem[K, V]).expireAt.Before(time.Now()) {
-			src.delete(k, v)
-			dst.Set(k, v.Value.(*lfuItem[K, V]).value)
+		item := v.Value.(*lfuItem[K, V])
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
+			src.stats.recordEviction(ReasonTransferred)
+			if src.events != nil {
+				src.events.fireEviction(k, item.value, ReasonTransferred)
+			}
 		}
 	}
+	src.m = make(map[K]*list.Element)
+	src.evictionList.Init()
+	src.usedCapacity = 0
+	src.bytesUsed = 0
+	if src.expQueue != nil {
+		src.expQueue = newExpirationQueue[K]()
+	}
 }
 
-// CopyTo copies all non-expired key-value pairs from the source cache to the destination cache.
-func (src *LFUCache[K, V]) CopyTo(dst *LFUCache[K, V]) {
+// CopyTo copies all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *LFUCache[K, V]) CopyTo(dst Cache[K, V]) {
 	src.mu.RLock()
 	defer src.mu.RUnlock()
 
 	for k, v := range src.m {
-		if v.Value.(*lfuItem[K, V]).expireAt == nil || !v.Value.(*lfuItem[K, V]).expireAt.Before(time.Now()) {
-			dst.Set(k, v.Value.(*lfuItem[K, V]).value)
+		if item := v.Value.(*lfuItem[K, V]); item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
 		}
 	}
 }
 
+// setValueWithTimeout inserts a value with an absolute expiration time, used internally by TransferTo/CopyTo
+// to move entries between cache implementations without losing their remaining TTL.
+func (l *LFUCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.loaderGrp.bump(k)
+	weight := l.weigher(k, v.value)
+	bytes := l.sizeOf(v.value)
+
+	var freq uint = 1
+	if item, ok := l.m[k]; ok {
+		old := item.Value.(*lfuItem[K, V])
+		freq = old.freq + 1
+		l.evictionList.Remove(item)
+		delete(l.m, k)
+		l.usedCapacity -= old.weight
+		l.bytesUsed -= l.sizeOf(old.value)
+	}
+
+	l.evictToFit(weight, bytes)
+
+	lfuItem := &lfuItem[K, V]{
+		key:      k,
+		value:    v.value,
+		expireAt: v.expireAt,
+		freq:     freq,
+		weight:   weight,
+	}
+	l.m[k] = l.evictionList.PushBack(lfuItem)
+	l.usedCapacity += weight
+	l.bytesUsed += bytes
+	l.move(l.m[k])
+	if v.expireAt != nil {
+		l.expQueue.push(k, *v.expireAt)
+	} else {
+		l.expQueue.remove(k)
+	}
+	l.janitor.wake()
+}
+
 // Keys returns a slice of all keys currently stored in the cache.
 // The returned slice does not include expired keys.
 // The order of keys in the slice is not guaranteed.
@@ -191,13 +575,169 @@ func (l *LFUCache[K, V]) Keys() []K {
 	return keys
 }
 
+// SaveTo writes every non-expired entry to w as a versioned gob stream, recording each
+// entry's remaining TTL (rather than its absolute expiration time) and its frequency
+// counter, so LoadFrom can rehydrate both the value and the key's standing relative to
+// its neighbors. K and V must be gob-encodable.
+func (l *LFUCache[K, V]) SaveTo(w io.Writer) error {
+	l.mu.RLock()
+	now := time.Now()
+	entries := make([]persistedLFUEntry[K, V], 0, len(l.m))
+	for k, v := range l.m {
+		item := v.Value.(*lfuItem[K, V])
+		if item.expireAt != nil && item.expireAt.Before(now) {
+			continue
+		}
+		var ttl time.Duration
+		if item.expireAt != nil {
+			ttl = item.expireAt.Sub(now)
+		}
+		entries = append(entries, persistedLFUEntry[K, V]{Key: k, Value: item.value, TTL: ttl, Freq: item.freq})
+	}
+	l.mu.RUnlock()
+
+	if err := writeSnapshotHeader(w); err != nil {
+		return fmt.Errorf("incache: write snapshot header: %w", err)
+	}
+	if err := gob.NewEncoder(w).Encode(entries); err != nil {
+		return fmt.Errorf("incache: encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadFrom reads a versioned gob stream written by SaveTo and inserts its entries into
+// the cache, giving each one a fresh TTL equal to what remained when it was saved and
+// restoring its frequency counter.
+func (l *LFUCache[K, V]) LoadFrom(r io.Reader) error {
+	if err := readSnapshotHeader(r); err != nil {
+		return err
+	}
+	var entries []persistedLFUEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("incache: decode snapshot: %w", err)
+	}
+	for _, e := range entries {
+		if e.TTL > 0 {
+			l.SetWithTimeout(e.Key, e.Value, e.TTL)
+		} else {
+			l.Set(e.Key, e.Value)
+		}
+		l.mu.Lock()
+		if elem, ok := l.m[e.Key]; ok {
+			elem.Value.(*lfuItem[K, V]).freq = e.Freq
+		}
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// SaveToFile writes a gob snapshot of the cache to path, creating or truncating it.
+func (l *LFUCache[K, V]) SaveToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("incache: create snapshot file: %w", err)
+	}
+	defer f.Close()
+	return l.SaveTo(f)
+}
+
+// LoadFromFile reads a gob snapshot previously written by SaveToFile and inserts its
+// entries into the cache.
+func (l *LFUCache[K, V]) LoadFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("incache: open snapshot file: %w", err)
+	}
+	defer f.Close()
+	return l.LoadFrom(f)
+}
+
+// SaveToJSON writes every non-expired entry to w as a versioned, indented JSON array,
+// for dumps a human can read directly rather than decoding with encoding/gob. K and V
+// must be JSON-marshalable.
+func (l *LFUCache[K, V]) SaveToJSON(w io.Writer) error {
+	l.mu.RLock()
+	now := time.Now()
+	entries := make([]persistedLFUEntry[K, V], 0, len(l.m))
+	for k, v := range l.m {
+		item := v.Value.(*lfuItem[K, V])
+		if item.expireAt != nil && item.expireAt.Before(now) {
+			continue
+		}
+		var ttl time.Duration
+		if item.expireAt != nil {
+			ttl = item.expireAt.Sub(now)
+		}
+		entries = append(entries, persistedLFUEntry[K, V]{Key: k, Value: item.value, TTL: ttl, Freq: item.freq})
+	}
+	l.mu.RUnlock()
+
+	if err := writeSnapshotHeader(w); err != nil {
+		return fmt.Errorf("incache: write snapshot header: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		return fmt.Errorf("incache: encode json snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadFromJSON reads a stream written by SaveToJSON and inserts its entries into the
+// cache, giving each one a fresh TTL equal to what remained when it was saved and
+// restoring its frequency counter.
+func (l *LFUCache[K, V]) LoadFromJSON(r io.Reader) error {
+	if err := readSnapshotHeader(r); err != nil {
+		return err
+	}
+	var entries []persistedLFUEntry[K, V]
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("incache: decode json snapshot: %w", err)
+	}
+	for _, e := range entries {
+		if e.TTL > 0 {
+			l.SetWithTimeout(e.Key, e.Value, e.TTL)
+		} else {
+			l.Set(e.Key, e.Value)
+		}
+		l.mu.Lock()
+		if elem, ok := l.m[e.Key]; ok {
+			elem.Value.(*lfuItem[K, V]).freq = e.Freq
+		}
+		l.mu.Unlock()
+	}
+	return nil
+}
+
 // Purge removes all key-value pairs from the cache.
 func (l *LFUCache[K, V]) Purge() {
+	if l.janitor != nil {
+		l.janitor.stop()
+	}
+	if l.persistor != nil {
+		l.persistor.stop()
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	for k, v := range l.m {
+		l.stats.recordEviction(ReasonPurged)
+		if l.events != nil {
+			l.events.fireEviction(k, v.Value.(*lfuItem[K, V]).value, ReasonPurged)
+		}
+	}
+	if l.events != nil {
+		l.events.stop()
+	}
+
 	l.m = make(map[K]*list.Element)
 	l.evictionList.Init()
+	l.usedCapacity = 0
+	l.bytesUsed = 0
+	if l.expQueue != nil {
+		l.expQueue = newExpirationQueue[K]()
+	}
 }
 
 // Count returns the number of non-expired key-value pairs currently stored in the cache.
@@ -228,12 +768,22 @@ func (l *LFUCache[K, V]) Delete(k K) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	item, ok := l.m[k]
+	l.loaderGrp.bump(k)
+	l.negCache.clear(k)
+	elem, ok := l.m[k]
 	if !ok {
 		return
 	}
 
-	l.delete(k, item)
+	lfuItem := elem.Value.(*lfuItem[K, V])
+	l.delete(k, elem)
+	l.usedCapacity -= lfuItem.weight
+	l.bytesUsed -= l.sizeOf(lfuItem.value)
+	l.expQueue.remove(k)
+	l.stats.recordEviction(ReasonDeleted)
+	if l.events != nil {
+		l.events.fireEviction(k, lfuItem.value, ReasonDeleted)
+	}
 }
 
 func (l *LFUCache[K, V]) delete(key K, elem *list.Element) {
@@ -244,8 +794,16 @@ func (l *LFUCache[K, V]) delete(key K, elem *list.Element) {
 func (l *LFUCache[K, V]) evict(n int) {
 	for i := 0; i < n; i++ {
 		if b := l.evictionList.Back(); b != nil {
-			delete(l.m, b.Value.(*lfuItem[K, V]).key)
+			item := b.Value.(*lfuItem[K, V])
+			delete(l.m, item.key)
 			l.evictionList.Remove(b)
+			l.usedCapacity -= item.weight
+			l.bytesUsed -= l.sizeOf(item.value)
+			l.expQueue.remove(item.key)
+			l.stats.recordEviction(ReasonCapacity)
+			if l.events != nil {
+				l.events.fireEviction(item.key, item.value, ReasonCapacity)
+			}
 		} else {
 			return
 		}
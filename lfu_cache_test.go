@@ -171,3 +171,48 @@ func TestLFUCache_Delete(t *testing.T) {
 		t.Errorf("Expected to get 'two', got '%v'", value)
 	}
 }
+
+func TestLFUCache_WithMaxBytes(t *testing.T) {
+	cb := New[int, string](10)
+	cb.EvictType(LFU)
+	cb.Sizer(func(v string) int64 { return int64(len(v)) })
+	cb.WithMaxBytes(12)
+	cache := cb.Build().(*LFUCache[int, string])
+
+	cache.Set(1, "aaaaa") // 5 bytes
+	cache.Set(2, "bbbbb") // 5 bytes, 10 total
+	cache.Set(3, "ccccc") // 5 bytes; pushes total to 15, over the 12-byte budget
+
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected key 1 to be evicted to stay within the byte capacity")
+	}
+
+	if _, ok := cache.Get(3); !ok {
+		t.Errorf("expected key 3 to be present")
+	}
+}
+
+func TestLFUCache_MemoryStats(t *testing.T) {
+	cb := New[int, string](10)
+	cb.EvictType(LFU)
+	cb.Sizer(func(v string) int64 { return int64(len(v)) })
+	cb.WithMaxBytes(100)
+	cache := cb.Build().(*LFUCache[int, string])
+
+	cache.Set(1, "abc")
+	cache.Set(2, "de")
+
+	length, bytes, capEntries, capBytes := cache.MemoryStats()
+	if length != 2 {
+		t.Errorf("MemoryStats length = %d, want 2", length)
+	}
+	if bytes != 5 {
+		t.Errorf("MemoryStats bytes = %d, want 5", bytes)
+	}
+	if capEntries != 10 {
+		t.Errorf("MemoryStats capEntries = %d, want 10", capEntries)
+	}
+	if capBytes != 100 {
+		t.Errorf("MemoryStats capBytes = %d, want 100", capBytes)
+	}
+}
@@ -0,0 +1,143 @@
+package incache
+
+import (
+	"sync"
+	"time"
+)
+
+// call represents an in-flight or completed loader invocation for a single key.
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// loaderGroup collapses concurrent loader invocations for the same key into one,
+// broadcasting the result to every waiter. It plays the role golang.org/x/sync/singleflight
+// would, without adding a dependency to a module-less tree.
+//
+// It also guards against a lost update: a direct Set/Delete landing on key while a load for
+// that same key is in flight must win over the loader's (by then stale) result. Every
+// direct mutation bumps the key's sequence number under the cache's own lock; do captures
+// the sequence number in effect when the load started and tells commit whether it is still
+// current, so commit can skip writing a stale value into the cache.
+type loaderGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+	seq   map[K]uint64
+}
+
+// bump advances key's sequence number, invalidating any load currently in flight for it.
+// Callers mutating a key directly (Set, SetWithTimeout, Delete, ...) must call this while
+// holding the cache's own lock, so the bump is ordered against any do call racing it.
+func (g *loaderGroup[K, V]) bump(key K) {
+	g.mu.Lock()
+	if g.seq == nil {
+		g.seq = make(map[K]uint64)
+	}
+	g.seq[key]++
+	g.mu.Unlock()
+}
+
+// do executes fn for key, or waits for an already in-flight call for the same key to
+// finish, then invokes commit with fn's result and whether key's sequence number is still
+// the one observed when the load began (false means a direct mutation raced the load and
+// must be left standing). commit runs once per fn invocation, only in the do call that ran
+// fn, after fn returns and without g.mu held; do calls that joined an in-flight load wait
+// for that commit and then return the shared result without calling their own commit.
+// It is commit's job to decide whether and how to write fn's result into the cache.
+func (g *loaderGroup[K, V]) do(key K, fn func() (V, error), commit func(val V, err error, fresh bool)) (V, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	if g.seq == nil {
+		g.seq = make(map[K]uint64)
+	}
+	startSeq := g.seq[key]
+
+	c := new(call[V])
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	fresh := g.seq[key] == startSeq
+	g.mu.Unlock()
+
+	commit(c.val, c.err, fresh)
+	c.wg.Done()
+
+	return c.val, c.err
+}
+
+// negativeEntry remembers a failed load and when that memory expires.
+type negativeEntry struct {
+	err      error
+	expireAt time.Time
+}
+
+// negativeCache briefly remembers that a key's loader returned an error, so a burst of
+// GetOrLoad calls for a persistently-failing key doesn't re-invoke the loader for every
+// one of them until the entry's TTL passes. A nil *negativeCache (the zero value of the
+// ttl configured via CacheBuilder.WithNegativeTTL) disables it entirely.
+type negativeCache[K comparable] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[K]negativeEntry
+}
+
+// newNegativeCache returns nil, disabling negative caching, if ttl is not positive.
+func newNegativeCache[K comparable](ttl time.Duration) *negativeCache[K] {
+	if ttl <= 0 {
+		return nil
+	}
+	return &negativeCache[K]{ttl: ttl, entries: make(map[K]negativeEntry)}
+}
+
+// get returns the error recorded for key, if any was recorded and it hasn't expired yet.
+func (n *negativeCache[K]) get(key K) (error, bool) {
+	if n == nil {
+		return nil, false
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	e, ok := n.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if e.expireAt.Before(time.Now()) {
+		delete(n.entries, key)
+		return nil, false
+	}
+	return e.err, true
+}
+
+// set records err against key for the configured TTL.
+func (n *negativeCache[K]) set(key K, err error) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.entries[key] = negativeEntry{err: err, expireAt: time.Now().Add(n.ttl)}
+}
+
+// clear removes any negative entry for key, called after a successful load.
+func (n *negativeCache[K]) clear(key K) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.entries, key)
+}
@@ -0,0 +1,212 @@
+package incache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoad_NoLoader(t *testing.T) {
+	c := New[int, string](10).Build()
+
+	if _, err := c.GetOrLoad(1); !errors.Is(err, ErrNoLoader) {
+		t.Errorf("expected ErrNoLoader, got %v", err)
+	}
+}
+
+func TestGetOrLoad_Hit(t *testing.T) {
+	c := New[int, string](10).Build()
+	c.Set(1, "one")
+
+	v, err := c.GetOrLoad(1)
+	if err != nil || v != "one" {
+		t.Errorf("expected ('one', nil), got (%q, %v)", v, err)
+	}
+}
+
+func TestGetOrLoad_LoadsOnMiss(t *testing.T) {
+	b := New[int, string](10)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		return "loaded", 0, nil
+	})
+	c := b.Build()
+
+	v, err := c.GetOrLoad(1)
+	if err != nil || v != "loaded" {
+		t.Errorf("expected ('loaded', nil), got (%q, %v)", v, err)
+	}
+
+	if v, ok := c.Get(1); !ok || v != "loaded" {
+		t.Errorf("expected loaded value to be cached, got (%q, %v)", v, ok)
+	}
+}
+
+func TestGetOrLoad_CollapsesConcurrentMisses(t *testing.T) {
+	var calls int32
+
+	b := New[int, string](10)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return "loaded", 0, nil
+	})
+	c := b.Build()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(1)
+			if err != nil || v != "loaded" {
+				t.Errorf("expected ('loaded', nil), got (%q, %v)", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected loader to be called once, got %d", got)
+	}
+}
+
+func TestGetOrLoad_RespectsLoaderTTL(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		b.Loader(func(k int) (string, time.Duration, error) {
+			return "loaded", 10 * time.Millisecond, nil
+		})
+		c := b.Build()
+
+		v, err := c.GetOrLoad(1)
+		if err != nil || v != "loaded" {
+			t.Errorf("%s: expected ('loaded', nil), got (%q, %v)", et, v, err)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		if _, ok := c.Get(1); ok {
+			t.Errorf("%s: expected entry loaded with a TTL to expire", et)
+		}
+	}
+}
+
+func TestGetOrLoad_ErrorsAreNotCached(t *testing.T) {
+	var calls int32
+	loadErr := errors.New("boom")
+
+	b := New[int, string](10)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return "", 0, loadErr
+	})
+	c := b.Build()
+
+	if _, err := c.GetOrLoad(1); !errors.Is(err, loadErr) {
+		t.Errorf("expected %v, got %v", loadErr, err)
+	}
+
+	if _, err := c.GetOrLoad(1); !errors.Is(err, loadErr) {
+		t.Errorf("expected %v, got %v", loadErr, err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected loader to be retried on a second miss, got %d calls", got)
+	}
+}
+
+func TestGetOrLoad_NegativeTTLSuppressesRetries(t *testing.T) {
+	var calls int32
+	loadErr := errors.New("boom")
+
+	b := New[int, string](10)
+	b.WithNegativeTTL(50 * time.Millisecond)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return "", 0, loadErr
+	})
+	c := b.Build()
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.GetOrLoad(1); !errors.Is(err, loadErr) {
+			t.Errorf("expected %v, got %v", loadErr, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected loader to be called once while the negative TTL is active, got %d calls", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := c.GetOrLoad(1); !errors.Is(err, loadErr) {
+		t.Errorf("expected %v, got %v", loadErr, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected loader to be retried once the negative TTL expired, got %d calls", got)
+	}
+}
+
+// TestGetOrLoad_DirectSetDuringLoadWins guards against a lost update: a direct Set landing
+// on a key while GetOrLoad's loader is still in flight for that same key must survive the
+// loader's eventual (by then stale) write, not be clobbered by it.
+func TestGetOrLoad_DirectSetDuringLoadWins(t *testing.T) {
+	loaderStarted := make(chan struct{})
+	releaseLoader := make(chan struct{})
+
+	b := New[int, string](10)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		close(loaderStarted)
+		<-releaseLoader
+		return "stale", 0, nil
+	})
+	c := b.Build()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.GetOrLoad(1)
+	}()
+
+	<-loaderStarted
+	c.Set(1, "fresh") // races the in-flight load
+	close(releaseLoader)
+	wg.Wait()
+
+	if v, ok := c.Get(1); !ok || v != "fresh" {
+		t.Errorf("expected the direct Set to win over the stale load, got (%q, %v)", v, ok)
+	}
+}
+
+func TestGetOrLoad_DeleteClearsNegativeEntry(t *testing.T) {
+	var fail int32 = 1
+
+	b := New[int, string](10)
+	b.WithNegativeTTL(time.Minute)
+	b.Loader(func(k int) (string, time.Duration, error) {
+		if atomic.LoadInt32(&fail) == 1 {
+			return "", 0, errors.New("boom")
+		}
+		return "loaded", 0, nil
+	})
+	c := b.Build()
+
+	if _, err := c.GetOrLoad(1); err == nil {
+		t.Fatalf("expected an error on the first load")
+	}
+
+	atomic.StoreInt32(&fail, 0)
+
+	// Delete forgets the negative entry too, so it doesn't linger as a false failure
+	// once the underlying problem is known to be resolved.
+	c.Delete(1)
+
+	v, err := c.GetOrLoad(1)
+	if err != nil || v != "loaded" {
+		t.Errorf("expected ('loaded', nil) once the underlying failure is resolved, got (%q, %v)", v, err)
+	}
+}
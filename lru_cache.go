@@ -2,6 +2,10 @@ package incache
 
 import (
 	"container/list"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 )
@@ -10,6 +14,7 @@ type lruItem[K comparable, V any] struct {
 	key      K
 	value    V
 	expireAt *time.Time
+	weight   uint64
 }
 
 // Least Recently Used Cache
@@ -18,6 +23,17 @@ type LRUCache[K comparable, V any] struct {
 	size         uint
 	m            map[K]*list.Element // where the key-value pairs are stored
 	evictionList *list.List
+	loader       func(K) (V, time.Duration, error)
+	loaderGrp    loaderGroup[K, V]
+	events       *eventDispatcher[K, V]
+	weigher      func(K, V) uint64
+	usedCapacity uint64
+	stats        statsCounters
+	janitor      *janitor
+	persistor    *persistor
+	expiryJitter float64
+	negCache     *negativeCache[K]
+	expQueue     *expirationQueue[K]
 }
 
 func NewLRU[K comparable, V any](size uint) *LRUCache[K, V] {
@@ -25,6 +41,74 @@ func NewLRU[K comparable, V any](size uint) *LRUCache[K, V] {
 		size:         size,
 		m:            make(map[K]*list.Element),
 		evictionList: list.New(),
+		weigher:      defaultWeigher[K, V],
+	}
+}
+
+// newLRU builds an LRUCache from a CacheBuilder, letting it be produced via New[K,V](size).EvictType(LRU).Build().
+func newLRU[K comparable, V any](cacheBuilder *CacheBuilder[K, V]) *LRUCache[K, V] {
+	c := NewLRU[K, V](cacheBuilder.size)
+	c.loader = cacheBuilder.loader
+	c.expiryJitter = cacheBuilder.expiryJitter
+	c.weigher = resolveWeigher[K, V](cacheBuilder.weigher)
+	c.negCache = newNegativeCache[K](cacheBuilder.negativeTTL)
+	if cacheBuilder.janitorStrategy == SweepHeap {
+		c.expQueue = newExpirationQueue[K]()
+	}
+	c.janitor = startJanitor(cacheBuilder.janitorInterval, cacheBuilder.janitorStrategy, c)
+	if cacheBuilder.onInsert != nil || cacheBuilder.onEvict != nil {
+		c.events = newEventDispatcher[K, V]()
+		if cacheBuilder.onInsert != nil {
+			c.events.onInsertion(cacheBuilder.onInsert)
+		}
+		if cacheBuilder.onEvict != nil {
+			c.events.onEviction(cacheBuilder.onEvict)
+		}
+	}
+	if cacheBuilder.persistPath != "" {
+		_ = c.LoadFromFile(cacheBuilder.persistPath)
+		c.persistor = startPersistor(cacheBuilder.persistInterval, cacheBuilder.persistPath, c)
+	}
+	return c
+}
+
+// OnInsertion registers fn to be called, on a background goroutine, whenever a key-value
+// pair is inserted into the cache. It returns a listener id usable with RemoveInsertionListener.
+func (c *LRUCache[K, V]) OnInsertion(fn func(K, V)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onInsertion(fn)
+}
+
+// RemoveInsertionListener unregisters a listener previously added with OnInsertion.
+func (c *LRUCache[K, V]) RemoveInsertionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeInsertionListener(id)
+	}
+}
+
+// OnEviction registers fn to be called, on a background goroutine, whenever a key-value
+// pair leaves the cache. It returns a listener id usable with RemoveEvictionListener.
+func (c *LRUCache[K, V]) OnEviction(fn func(K, V, EvictionReason)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onEviction(fn)
+}
+
+// RemoveEvictionListener unregisters a listener previously added with OnEviction.
+func (c *LRUCache[K, V]) RemoveEvictionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeEvictionListener(id)
 	}
 }
 
@@ -37,6 +121,7 @@ func (c *LRUCache[K, V]) Get(k K) (v V, b bool) {
 
 	item, ok := c.m[k]
 	if !ok {
+		c.stats.misses.Add(1)
 		return
 	}
 
@@ -44,10 +129,18 @@ func (c *LRUCache[K, V]) Get(k K) (v V, b bool) {
 	if lruItem.expireAt != nil && lruItem.expireAt.Before(time.Now()) {
 		delete(c.m, k)
 		c.evictionList.Remove(item)
+		c.usedCapacity -= lruItem.weight
+		c.expQueue.remove(k)
+		c.stats.misses.Add(1)
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, lruItem.value, ReasonExpired)
+		}
 		return
 	}
 
 	c.evictionList.MoveToFront(item)
+	c.stats.hits.Add(1)
 
 	return lruItem.value, true
 }
@@ -116,6 +209,51 @@ func (c *LRUCache[K, V]) NotFoundSetWithTimeout(k K, v V, t time.Duration) bool
 	return true
 }
 
+// GetOrLoad retrieves the value associated with the given key, invoking the configured
+// loader on a miss. Concurrent calls for the same key coalesce into a single loader invocation.
+// It returns ErrNoLoader if no loader was configured via CacheBuilder.Loader.
+func (c *LRUCache[K, V]) GetOrLoad(k K) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	if err, ok := c.negCache.get(k); ok {
+		var zero V
+		return zero, err
+	}
+	if c.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	var ttl time.Duration
+	var loaded bool
+	return c.loaderGrp.do(k, func() (V, error) {
+		if v, ok := c.Get(k); ok {
+			return v, nil
+		}
+		if err, ok := c.negCache.get(k); ok {
+			var zero V
+			return zero, err
+		}
+		start := time.Now()
+		v, d, err := c.loader(k)
+		c.stats.recordLoad(time.Since(start), err)
+		ttl = d
+		loaded = true
+		return v, err
+	}, func(v V, err error, fresh bool) {
+		if !loaded || !fresh {
+			return
+		}
+		if err != nil {
+			c.negCache.set(k, err)
+			return
+		}
+		c.negCache.clear(k)
+		c.SetWithTimeout(k, v, ttl)
+	})
+}
+
 // Delete removes the key-value pair associated with the given key from the cache.
 func (c *LRUCache[K, V]) Delete(k K) {
 	c.mu.Lock()
@@ -125,41 +263,94 @@ func (c *LRUCache[K, V]) Delete(k K) {
 }
 
 func (c *LRUCache[K, V]) delete(k K) {
+	c.loaderGrp.bump(k)
+	c.negCache.clear(k)
 	item, ok := c.m[k]
 	if !ok {
 		return
 	}
 
+	lruItem := item.Value.(*lruItem[K, V])
 	delete(c.m, k)
 	c.evictionList.Remove(item)
+	c.usedCapacity -= lruItem.weight
+	c.expQueue.remove(k)
+	c.stats.recordEviction(ReasonDeleted)
+
+	if c.events != nil {
+		c.events.fireEviction(k, lruItem.value, ReasonDeleted)
+	}
 }
 
-// TransferTo transfers all non-expired key-value pairs from the source cache to the destination cache.
-func (src *LRUCache[K, V]) TransferTo(dst *LRUCache[K, V]) {
+// TransferTo transfers all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *LRUCache[K, V]) TransferTo(dst Cache[K, V]) {
 	src.mu.Lock()
 	defer src.mu.Unlock()
 
 	for k, v := range src.m {
 		lruItem := v.Value.(*lruItem[K, V])
 		if lruItem.expireAt == nil || !lruItem.expireAt.Before(time.Now()) {
-			src.delete(k)
-			dst.Set(k, lruItem.value)
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: lruItem.value, expireAt: lruItem.expireAt})
+			src.stats.recordEviction(ReasonTransferred)
+			if src.events != nil {
+				src.events.fireEviction(k, lruItem.value, ReasonTransferred)
+			}
 		}
 	}
+	src.m = make(map[K]*list.Element)
+	src.evictionList.Init()
+	src.usedCapacity = 0
+	if src.expQueue != nil {
+		src.expQueue = newExpirationQueue[K]()
+	}
 }
 
-// CopyTo copies all non-expired key-value pairs from the source cache to the destination cache.
-func (src *LRUCache[K, V]) CopyTo(dst *LRUCache[K, V]) {
-	src.mu.Lock()
-	defer src.mu.Unlock()
+// CopyTo copies all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *LRUCache[K, V]) CopyTo(dst Cache[K, V]) {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
 
 	for k, v := range src.m {
 		if lruItem := v.Value.(*lruItem[K, V]); lruItem.expireAt == nil || !lruItem.expireAt.Before(time.Now()) {
-			dst.Set(k, lruItem.value)
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: lruItem.value, expireAt: lruItem.expireAt})
 		}
 	}
 }
 
+// setValueWithTimeout inserts a value with an absolute expiration time, used internally by TransferTo/CopyTo
+// to move entries between cache implementations without losing their remaining TTL.
+func (c *LRUCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaderGrp.bump(k)
+	weight := c.weigher(k, v.value)
+
+	if item, ok := c.m[k]; ok {
+		old := item.Value.(*lruItem[K, V])
+		delete(c.m, k)
+		c.evictionList.Remove(item)
+		c.usedCapacity -= old.weight
+	}
+
+	c.evictToFit(weight)
+
+	lruItem := &lruItem[K, V]{
+		key:      k,
+		value:    v.value,
+		expireAt: v.expireAt,
+		weight:   weight,
+	}
+	c.m[k] = c.evictionList.PushFront(lruItem)
+	c.usedCapacity += weight
+	if v.expireAt != nil {
+		c.expQueue.push(k, *v.expireAt)
+	} else {
+		c.expQueue.remove(k)
+	}
+	c.janitor.wake()
+}
+
 // Keys returns a slice of all keys currently stored in the cache.
 // The returned slice does not include expired keys.
 // The order of keys in the slice is not guaranteed.
@@ -178,13 +369,104 @@ func (c *LRUCache[K, V]) Keys() []K {
 	return keys
 }
 
+// SaveTo writes every non-expired entry to w as a versioned gob stream, recording each
+// entry's remaining TTL rather than its absolute expiration time, so LoadFrom can
+// rehydrate it relative to when it runs. K and V must be gob-encodable.
+func (c *LRUCache[K, V]) SaveTo(w io.Writer) error {
+	c.mu.RLock()
+	now := time.Now()
+	entries := make([]persistedEntry[K, V], 0, len(c.m))
+	for k, v := range c.m {
+		lruItem := v.Value.(*lruItem[K, V])
+		if lruItem.expireAt != nil && lruItem.expireAt.Before(now) {
+			continue
+		}
+		var ttl time.Duration
+		if lruItem.expireAt != nil {
+			ttl = lruItem.expireAt.Sub(now)
+		}
+		entries = append(entries, persistedEntry[K, V]{Key: k, Value: lruItem.value, TTL: ttl})
+	}
+	c.mu.RUnlock()
+
+	if err := writeSnapshotHeader(w); err != nil {
+		return fmt.Errorf("incache: write snapshot header: %w", err)
+	}
+	if err := gob.NewEncoder(w).Encode(entries); err != nil {
+		return fmt.Errorf("incache: encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadFrom reads a versioned gob stream written by SaveTo and inserts its entries into
+// the cache, giving each one a fresh TTL equal to what remained when it was saved.
+func (c *LRUCache[K, V]) LoadFrom(r io.Reader) error {
+	if err := readSnapshotHeader(r); err != nil {
+		return err
+	}
+	var entries []persistedEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("incache: decode snapshot: %w", err)
+	}
+	for _, e := range entries {
+		if e.TTL > 0 {
+			c.SetWithTimeout(e.Key, e.Value, e.TTL)
+		} else {
+			c.Set(e.Key, e.Value)
+		}
+	}
+	return nil
+}
+
+// SaveToFile writes a gob snapshot of the cache to path, creating or truncating it.
+func (c *LRUCache[K, V]) SaveToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("incache: create snapshot file: %w", err)
+	}
+	defer f.Close()
+	return c.SaveTo(f)
+}
+
+// LoadFromFile reads a gob snapshot previously written by SaveToFile and inserts its
+// entries into the cache.
+func (c *LRUCache[K, V]) LoadFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("incache: open snapshot file: %w", err)
+	}
+	defer f.Close()
+	return c.LoadFrom(f)
+}
+
 // Purge removes all key-value pairs from the cache.
 func (c *LRUCache[K, V]) Purge() {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+	if c.persistor != nil {
+		c.persistor.stop()
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	for k, v := range c.m {
+		c.stats.recordEviction(ReasonPurged)
+		if c.events != nil {
+			c.events.fireEviction(k, v.Value.(*lruItem[K, V]).value, ReasonPurged)
+		}
+	}
+	if c.events != nil {
+		c.events.stop()
+	}
+
 	c.m = make(map[K]*list.Element)
 	c.evictionList.Init()
+	c.usedCapacity = 0
+	if c.expQueue != nil {
+		c.expQueue = newExpirationQueue[K]()
+	}
 }
 
 // Count returns the number of non-expired key-value pairs currently stored in the cache.
@@ -210,39 +492,204 @@ func (c *LRUCache[K, V]) Len() int {
 	return len(c.m)
 }
 
-func (c *LRUCache[K, V]) set(k K, v V, exp time.Duration) {
+// evictToFit evicts entries until adding weight more would not exceed the cache's capacity,
+// or the cache is empty. Callers must hold c.mu.
+func (c *LRUCache[K, V]) evictToFit(weight uint64) {
+	for c.usedCapacity+weight > uint64(c.size) && c.evictionList.Len() > 0 {
+		c.evict(1)
+	}
+}
+
+// expireIfPast removes k if it has expired, firing a ReasonExpired eviction and recording
+// it in stats. Callers must hold c.mu.
+func (c *LRUCache[K, V]) expireIfPast(k K) bool {
 	item, ok := c.m[k]
+	if !ok {
+		return false
+	}
+	lruItem := item.Value.(*lruItem[K, V])
+	if lruItem.expireAt == nil || !lruItem.expireAt.Before(time.Now()) {
+		return false
+	}
+	delete(c.m, k)
+	c.evictionList.Remove(item)
+	c.usedCapacity -= lruItem.weight
+	c.expQueue.remove(k)
+	c.stats.recordEviction(ReasonExpired)
+	if c.events != nil {
+		c.events.fireEviction(k, lruItem.value, ReasonExpired)
+	}
+	return true
+}
+
+// nextExpiry returns the expiry time of the soonest-expiring entry still tracked in the
+// cache's expirationQueue, for janitor.runHeap. Only meaningful when built with SweepHeap.
+func (c *LRUCache[K, V]) nextExpiry() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, expireAt, ok := c.expQueue.peek()
+	return expireAt, ok
+}
+
+// sweepDue removes every entry whose expiry has already passed, for janitor.runHeap.
+func (c *LRUCache[K, V]) sweepDue() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for {
+		k, expireAt, ok := c.expQueue.peek()
+		if !ok || expireAt.After(now) {
+			return
+		}
+		c.expQueue.pop()
+		item, ok := c.m[k]
+		if !ok {
+			continue
+		}
+		lruItem := item.Value.(*lruItem[K, V])
+		delete(c.m, k)
+		c.evictionList.Remove(item)
+		c.usedCapacity -= lruItem.weight
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, lruItem.value, ReasonExpired)
+		}
+	}
+}
+
+// sweepFull removes every currently expired entry, processing at most batchSize entries
+// per lock acquisition so the janitor never holds the write lock for the whole cache.
+func (c *LRUCache[K, V]) sweepFull(batchSize int) {
+	c.mu.RLock()
+	keys := make([]K, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		c.mu.Lock()
+		for _, k := range keys[start:end] {
+			c.expireIfPast(k)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// sweepSampled inspects up to n entries, chosen via Go's randomized map iteration order,
+// and removes the ones that have expired.
+func (c *LRUCache[K, V]) sweepSampled(n int) (sampled, expired int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.m {
+		if sampled >= n {
+			break
+		}
+		sampled++
+		if c.expireIfPast(k) {
+			expired++
+		}
+	}
+	return
+}
+
+func (c *LRUCache[K, V]) set(k K, v V, exp time.Duration) {
+	c.loaderGrp.bump(k)
 	var tm *time.Time
 	if exp > 0 {
-		t := time.Now().Add(exp)
+		t := time.Now().Add(applyJitter(exp, c.expiryJitter))
 		tm = &t
 	}
-	if ok {
-		lruItem := item.Value.(*lruItem[K, V])
-		lruItem.value = v
-		lruItem.expireAt = tm
-		c.evictionList.MoveToFront(item)
-	} else {
-		if len(c.m) == int(c.size) {
-			c.evict(1)
-		}
+	weight := c.weigher(k, v)
 
-		lruItem := &lruItem[K, V]{
-			key:      k,
-			value:    v,
-			expireAt: tm,
+	if item, ok := c.m[k]; ok {
+		old := item.Value.(*lruItem[K, V])
+		delete(c.m, k)
+		c.evictionList.Remove(item)
+		c.usedCapacity -= old.weight
+		c.stats.recordEviction(ReasonDeleted)
+		if c.events != nil {
+			c.events.fireEviction(k, old.value, ReasonDeleted)
 		}
+	}
 
-		insertedItem := c.evictionList.PushFront(lruItem)
-		c.m[k] = insertedItem
+	c.evictToFit(weight)
+
+	lruItem := &lruItem[K, V]{
+		key:      k,
+		value:    v,
+		expireAt: tm,
+		weight:   weight,
 	}
+	c.m[k] = c.evictionList.PushFront(lruItem)
+	c.usedCapacity += weight
+	c.stats.insertions.Add(1)
+	if tm != nil {
+		c.expQueue.push(k, *tm)
+		c.janitor.wake()
+	} else {
+		c.expQueue.remove(k)
+	}
+
+	if c.events != nil {
+		c.events.fireInsertion(k, v)
+	}
+}
+
+// TrySet adds or updates the key-value pair in the cache, like Set, but rejects the entry
+// with ErrWeightExceedsCapacity if its weight alone exceeds the cache's capacity.
+func (c *LRUCache[K, V]) TrySet(k K, v V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.weigher(k, v) > uint64(c.size) {
+		return ErrWeightExceedsCapacity
+	}
+
+	c.set(k, v, 0)
+	return nil
+}
+
+// Used returns the total weight of all entries currently stored in the cache.
+func (c *LRUCache[K, V]) Used() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usedCapacity
+}
+
+// Capacity returns the cache's capacity in weight units, as configured by New.
+func (c *LRUCache[K, V]) Capacity() uint64 {
+	return uint64(c.size)
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction/load counters.
+func (c *LRUCache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the cache's hit/miss/eviction/load counters.
+func (c *LRUCache[K, V]) ResetStats() {
+	c.stats.reset()
 }
 
 func (c *LRUCache[K, V]) evict(i int) {
 	for j := 0; j < i; j++ {
 		if b := c.evictionList.Back(); b != nil {
-			delete(c.m, b.Value.(*lruItem[K, V]).key)
+			lruItem := b.Value.(*lruItem[K, V])
+			delete(c.m, lruItem.key)
 			c.evictionList.Remove(b)
+			c.usedCapacity -= lruItem.weight
+			c.expQueue.remove(lruItem.key)
+			c.stats.recordEviction(ReasonCapacity)
+			if c.events != nil {
+				c.events.fireEviction(lruItem.key, lruItem.value, ReasonCapacity)
+			}
 		} else {
 			return
 		}
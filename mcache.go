@@ -1,60 +1,182 @@
-package inmemdb
+package incache
 
 import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
 	"time"
 )
 
+// MCache is a manually-evicted, in-memory cache. It is a thin handle around an
+// mcacheCore: the janitor goroutine holds a reference to the core, not to MCache itself,
+// so a finalizer on MCache can still run and stop that goroutine if a caller drops the
+// cache without calling Purge. See hashicorp/golang-lru's expirable cache for the same
+// split, and the pitfall it avoids: a finalizer never runs on an object a live goroutine
+// still points to.
 type MCache[K comparable, V any] struct {
+	*mcacheCore[K, V]
+}
+
+type mcacheCore[K comparable, V any] struct {
 	baseCache
 	m            map[K]valueWithTimeout[V]
-	stopCh       chan struct{} // Channel to signal timeout goroutine to stop
-	timeInterval time.Duration // Time interval to sleep the goroutine that checks for expired keys
+	janitor      *janitor
+	persistor    *persistor
+	loader       func(K) (V, time.Duration, error)
+	loaderGrp    loaderGroup[K, V]
+	events       *eventDispatcher[K, V]
+	weigher      func(K, V) uint64
+	usedCapacity uint64
+	stats        statsCounters
+	expiryJitter float64
+	negCache     *negativeCache[K]
+	expQueue     *expirationQueue[K]
 }
 
 type valueWithTimeout[V any] struct {
 	value    V
 	expireAt *time.Time
+	weight   uint64
 }
 
 // New creates a new in-memory database instance with optional configuration provided by the specified options.
-// The database starts a background goroutine to periodically check for expired keys based on the configured time interval.
+// If a Janitor was configured, the database starts a background goroutine that sweeps expired keys on the configured interval.
 func newManual[K comparable, V any](cacheBuilder *CacheBuilder[K, V]) *MCache[K, V] {
-	db := &MCache[K, V]{
+	weigher := resolveWeigher[K, V](cacheBuilder.weigher)
+	core := &mcacheCore[K, V]{
 		m:            make(map[K]valueWithTimeout[V]),
-		stopCh:       make(chan struct{}),
-		timeInterval: cacheBuilder.tmIvl,
+		loader:       cacheBuilder.loader,
+		weigher:      weigher,
+		expiryJitter: cacheBuilder.expiryJitter,
+		negCache:     newNegativeCache[K](cacheBuilder.negativeTTL),
 		baseCache: baseCache{
 			size: cacheBuilder.size,
 		},
 	}
-	if db.timeInterval > 0 {
-		go db.expireKeys()
+	if cacheBuilder.janitorStrategy == SweepHeap {
+		core.expQueue = newExpirationQueue[K]()
 	}
+	core.janitor = startJanitor(cacheBuilder.janitorInterval, cacheBuilder.janitorStrategy, core)
+	if cacheBuilder.onInsert != nil || cacheBuilder.onEvict != nil {
+		core.events = newEventDispatcher[K, V]()
+		if cacheBuilder.onInsert != nil {
+			core.events.onInsertion(cacheBuilder.onInsert)
+		}
+		if cacheBuilder.onEvict != nil {
+			core.events.onEviction(cacheBuilder.onEvict)
+		}
+	}
+	if cacheBuilder.persistPath != "" {
+		_ = core.LoadFromFile(cacheBuilder.persistPath)
+		core.persistor = startPersistor(cacheBuilder.persistInterval, cacheBuilder.persistPath, core)
+	}
+
+	db := &MCache[K, V]{core}
+	runtime.SetFinalizer(db, func(m *MCache[K, V]) {
+		if m.janitor != nil {
+			m.janitor.stop()
+		}
+		if m.persistor != nil {
+			m.persistor.stop()
+		}
+		if m.events != nil {
+			m.events.stop()
+		}
+	})
 	return db
 }
 
-// Set adds or updates a key-value pair in the database without setting an expiration time.
-// If the key already exists, its value will be overwritten with the new value.
-// This function is safe for concurrent use.
-func (c *MCache[K, V]) Set(k K, v V) {
-	if c.size == 0 {
-		return
+// OnInsertion registers fn to be called, on a background goroutine, whenever a key-value
+// pair is inserted into the database. It returns a listener id usable with RemoveInsertionListener.
+func (c *mcacheCore[K, V]) OnInsertion(fn func(K, V)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
 	}
+	return c.events.onInsertion(fn)
+}
 
+// RemoveInsertionListener unregisters a listener previously added with OnInsertion.
+func (c *mcacheCore[K, V]) RemoveInsertionListener(id int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeInsertionListener(id)
+	}
+}
 
-	if len(c.m) == int(c.size) {
+// OnEviction registers fn to be called, on a background goroutine, whenever a key-value
+// pair leaves the database. It returns a listener id usable with RemoveEvictionListener.
+func (c *mcacheCore[K, V]) OnEviction(fn func(K, V, EvictionReason)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onEviction(fn)
+}
+
+// RemoveEvictionListener unregisters a listener previously added with OnEviction.
+func (c *mcacheCore[K, V]) RemoveEvictionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeEvictionListener(id)
+	}
+}
+
+// evictToFit evicts entries until adding weight more would not exceed the cache's capacity,
+// or the cache is empty. Callers must hold c.mu.
+func (c *mcacheCore[K, V]) evictToFit(weight uint64) {
+	for c.usedCapacity+weight > uint64(c.size) && len(c.m) > 0 {
 		c.evict(1)
 	}
+}
+
+// setEntry inserts or overwrites k with weight/capacity bookkeeping and fires the
+// insertion/eviction events. Callers must hold c.mu.
+func (c *mcacheCore[K, V]) setEntry(k K, v V, expireAt *time.Time) {
+	c.loaderGrp.bump(k)
+	weight := c.weigher(k, v)
+
+	if old, ok := c.m[k]; ok {
+		delete(c.m, k)
+		c.usedCapacity -= old.weight
+		c.stats.recordEviction(ReasonDeleted)
+		if c.events != nil {
+			c.events.fireEviction(k, old.value, ReasonDeleted)
+		}
+	}
+
+	c.evictToFit(weight)
 
 	c.m[k] = valueWithTimeout[V]{
 		value:    v,
-		expireAt: nil,
+		expireAt: expireAt,
+		weight:   weight,
+	}
+	c.usedCapacity += weight
+	c.stats.insertions.Add(1)
+
+	if expireAt != nil {
+		c.expQueue.push(k, *expireAt)
+		c.janitor.wake()
+	} else {
+		c.expQueue.remove(k)
+	}
+
+	if c.events != nil {
+		c.events.fireInsertion(k, v)
 	}
 }
 
-func (c *MCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+// Set adds or updates a key-value pair in the database without setting an expiration time.
+// If the key already exists, its value will be overwritten with the new value.
+// This function is safe for concurrent use.
+func (c *mcacheCore[K, V]) Set(k K, v V) {
 	if c.size == 0 {
 		return
 	}
@@ -62,15 +184,41 @@ func (c *MCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if len(c.m) == int(c.size) {
-		c.evict(1)
+	c.setEntry(k, v, nil)
+}
+
+func (c *mcacheCore[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+	if c.size == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaderGrp.bump(k)
+	weight := c.weigher(k, v.value)
+	if old, ok := c.m[k]; ok {
+		c.usedCapacity -= old.weight
 	}
+	c.evictToFit(weight)
 
-	c.m[k] = v
+	c.m[k] = valueWithTimeout[V]{
+		value:    v.value,
+		expireAt: v.expireAt,
+		weight:   weight,
+	}
+	c.usedCapacity += weight
+
+	if v.expireAt != nil {
+		c.expQueue.push(k, *v.expireAt)
+		c.janitor.wake()
+	} else {
+		c.expQueue.remove(k)
+	}
 }
 
 // NotFoundSet adds a key-value pair to the database if the key does not already exist and returns true. Otherwise, it does nothing and returns false.
-func (c *MCache[K, V]) NotFoundSet(k K, v V) bool {
+func (c *mcacheCore[K, V]) NotFoundSet(k K, v V) bool {
 	if c.size == 0 {
 		return false
 	}
@@ -78,24 +226,18 @@ func (c *MCache[K, V]) NotFoundSet(k K, v V) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	_, ok := c.m[k]
-	if !ok {
-		if len(c.m) == int(c.size) {
-			c.evict(1)
-		}
-
-		c.m[k] = valueWithTimeout[V]{
-			value:    v,
-			expireAt: nil,
-		}
+	if _, ok := c.m[k]; ok {
+		return false
 	}
-	return !ok
+
+	c.setEntry(k, v, nil)
+	return true
 }
 
 // SetWithTimeout adds or updates a key-value pair in the database with an expiration time.
 // If the timeout duration is zero or negative, the key-value pair will not have an expiration time.
 // This function is safe for concurrent use.
-func (c *MCache[K, V]) SetWithTimeout(k K, v V, timeout time.Duration) {
+func (c *mcacheCore[K, V]) SetWithTimeout(k K, v V, timeout time.Duration) {
 	if c.size == 0 {
 		return
 	}
@@ -103,15 +245,8 @@ func (c *MCache[K, V]) SetWithTimeout(k K, v V, timeout time.Duration) {
 		c.mu.Lock()
 		defer c.mu.Unlock()
 
-		if len(c.m) == int(c.size) {
-			c.evict(1)
-		}
-
-		now := time.Now().Add(timeout)
-		c.m[k] = valueWithTimeout[V]{
-			value:    v,
-			expireAt: &now,
-		}
+		now := time.Now().Add(applyJitter(timeout, c.expiryJitter))
+		c.setEntry(k, v, &now)
 	} else {
 		c.Set(k, v)
 	}
@@ -120,7 +255,7 @@ func (c *MCache[K, V]) SetWithTimeout(k K, v V, timeout time.Duration) {
 // NotFoundSetWithTimeout adds a key-value pair to the database with an expiration time if the key does not already exist and returns true. Otherwise, it does nothing and returns false.
 // If the timeout is zero or negative, the key-value pair will not have an expiration time.
 // If expiry is disabled, it behaves like NotFoundSet.
-func (c *MCache[K, V]) NotFoundSetWithTimeout(k K, v V, timeout time.Duration) bool {
+func (c *mcacheCore[K, V]) NotFoundSetWithTimeout(k K, v V, timeout time.Duration) bool {
 	if c.size == 0 {
 		return false
 	}
@@ -128,74 +263,182 @@ func (c *MCache[K, V]) NotFoundSetWithTimeout(k K, v V, timeout time.Duration) b
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	var ok bool
+	if _, ok := c.m[k]; ok {
+		return false
+	}
+
+	var expireAt *time.Time
 	if timeout > 0 {
-		now := time.Now().Add(timeout)
-		_, ok = c.m[k]
-		if !ok {
-			if len(c.m) == int(c.size) {
-				c.evict(1)
-			}
+		now := time.Now().Add(applyJitter(timeout, c.expiryJitter))
+		expireAt = &now
+	}
+	c.setEntry(k, v, expireAt)
+	return true
+}
 
-			c.m[k] = valueWithTimeout[V]{
-				value:    v,
-				expireAt: &now,
-			}
-		}
-	} else {
-		_, ok = c.m[k]
-		if !ok {
-			if len(c.m) == int(c.size) {
-				c.evict(1)
-			}
+// TrySet adds or updates the key-value pair in the cache, like Set, but rejects the entry
+// with ErrWeightExceedsCapacity if its weight alone exceeds the cache's capacity.
+func (c *mcacheCore[K, V]) TrySet(k K, v V) error {
+	if c.size == 0 {
+		return ErrWeightExceedsCapacity
+	}
 
-			c.m[k] = valueWithTimeout[V]{
-				value:    v,
-				expireAt: nil,
-			}
-		}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.weigher(k, v) > uint64(c.size) {
+		return ErrWeightExceedsCapacity
 	}
-	return !ok
+
+	c.setEntry(k, v, nil)
+	return nil
+}
+
+// Used returns the total weight of all entries currently stored in the database.
+func (c *mcacheCore[K, V]) Used() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usedCapacity
 }
 
-func (c *MCache[K, V]) Get(k K) (v V, b bool) {
+// Capacity returns the database's capacity in weight units, as configured by New.
+func (c *mcacheCore[K, V]) Capacity() uint64 {
+	return uint64(c.size)
+}
+
+// Stats returns a snapshot of the database's hit/miss/eviction/load counters.
+func (c *mcacheCore[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the database's hit/miss/eviction/load counters.
+func (c *mcacheCore[K, V]) ResetStats() {
+	c.stats.reset()
+}
+
+func (c *mcacheCore[K, V]) Get(k K) (v V, b bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	val, ok := c.m[k]
 	if !ok {
+		c.stats.misses.Add(1)
 		return
 	}
 	if val.expireAt != nil && val.expireAt.Before(time.Now()) {
 		delete(c.m, k)
+		c.usedCapacity -= val.weight
+		c.stats.misses.Add(1)
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, val.value, ReasonExpired)
+		}
 		return
 	}
+	c.stats.hits.Add(1)
 	return val.value, ok
 }
 
-func (c *MCache[K, V]) Delete(k K) {
+// GetAll returns all non-expired key-value pairs currently stored in the database.
+func (c *mcacheCore[K, V]) GetAll() map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := make(map[K]V, len(c.m))
+	for k, v := range c.m {
+		if v.expireAt == nil || !v.expireAt.Before(time.Now()) {
+			m[k] = v.value
+		}
+	}
+	return m
+}
+
+// GetOrLoad retrieves the value associated with the given key, invoking the configured
+// loader on a miss. Concurrent calls for the same key coalesce into a single loader invocation.
+// It returns ErrNoLoader if no loader was configured via CacheBuilder.Loader.
+func (c *mcacheCore[K, V]) GetOrLoad(k K) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	if err, ok := c.negCache.get(k); ok {
+		var zero V
+		return zero, err
+	}
+	if c.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	var ttl time.Duration
+	var loaded bool
+	return c.loaderGrp.do(k, func() (V, error) {
+		if v, ok := c.Get(k); ok {
+			return v, nil
+		}
+		if err, ok := c.negCache.get(k); ok {
+			var zero V
+			return zero, err
+		}
+		start := time.Now()
+		v, d, err := c.loader(k)
+		c.stats.recordLoad(time.Since(start), err)
+		ttl = d
+		loaded = true
+		return v, err
+	}, func(v V, err error, fresh bool) {
+		if !loaded || !fresh {
+			return
+		}
+		if err != nil {
+			c.negCache.set(k, err)
+			return
+		}
+		c.negCache.clear(k)
+		c.SetWithTimeout(k, v, ttl)
+	})
+}
+
+func (c *mcacheCore[K, V]) Delete(k K) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.m, k)
+	c.loaderGrp.bump(k)
+	c.negCache.clear(k)
+	if old, ok := c.m[k]; ok {
+		delete(c.m, k)
+		c.expQueue.remove(k)
+		c.usedCapacity -= old.weight
+		c.stats.recordEviction(ReasonDeleted)
+		if c.events != nil {
+			c.events.fireEviction(k, old.value, ReasonDeleted)
+		}
+	}
 }
 
 // TransferTo transfers all key-value pairs from the source DB to the provided destination DB.
 //
 // The source DB is locked during the entire operation, and the destination DB is locked for the duration of the function call.
 // The function is safe to call concurrently with other operations on any of the source DB or Destination DB.
-func (src *MCache[K, V]) TransferTo(dst Cache[K, V]) {
+func (src *mcacheCore[K, V]) TransferTo(dst Cache[K, V]) {
 	src.mu.Lock()
 	defer src.mu.Unlock()
 	for k, v := range src.m {
 		dst.setValueWithTimeout(k, v)
+		src.stats.recordEviction(ReasonTransferred)
+		if src.events != nil {
+			src.events.fireEviction(k, v.value, ReasonTransferred)
+		}
 	}
 	src.m = make(map[K]valueWithTimeout[V])
+	src.usedCapacity = 0
+	if src.expQueue != nil {
+		src.expQueue = newExpirationQueue[K]()
+	}
 }
 
 // CopyTo copies all key-value pairs from the source DB to the provided destination DB.
 //
 // The source DB is locked during the entire operation, and the destination DB is locked for the duration of the function call.
 // The function is safe to call concurrently with other operations on any of the source DB or Destination DB.
-func (src *MCache[K, V]) CopyTo(dst Cache[K, V]) {
+func (src *mcacheCore[K, V]) CopyTo(dst Cache[K, V]) {
 	src.mu.RLock()
 	defer src.mu.RUnlock()
 	for k, v := range src.m {
@@ -204,7 +447,7 @@ func (src *MCache[K, V]) CopyTo(dst Cache[K, V]) {
 }
 
 // Keys returns a slice containing the keys of the map in random order.
-func (c *MCache[K, V]) Keys() []K {
+func (c *mcacheCore[K, V]) Keys() []K {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -218,62 +461,246 @@ func (c *MCache[K, V]) Keys() []K {
 	return keys
 }
 
-// expireKeys is a background goroutine that periodically checks for expired keys and removes them from the database.
-// It runs until the Close method is callec.
-// This function is not intended to be called directly by users.
-func (c *MCache[K, V]) expireKeys() {
-	ticker := time.NewTicker(c.timeInterval)
-	defer ticker.Stop()
+// SaveTo writes every non-expired entry to w as a versioned gob stream, recording each
+// entry's remaining TTL rather than its absolute expiration time, so LoadFrom can
+// rehydrate it relative to when it runs. K and V must be gob-encodable.
+func (c *mcacheCore[K, V]) SaveTo(w io.Writer) error {
+	c.mu.RLock()
+	now := time.Now()
+	entries := make([]persistedEntry[K, V], 0, len(c.m))
+	for k, v := range c.m {
+		if v.expireAt != nil && v.expireAt.Before(now) {
+			continue
+		}
+		var ttl time.Duration
+		if v.expireAt != nil {
+			ttl = v.expireAt.Sub(now)
+		}
+		entries = append(entries, persistedEntry[K, V]{Key: k, Value: v.value, TTL: ttl})
+	}
+	c.mu.RUnlock()
+
+	if err := writeSnapshotHeader(w); err != nil {
+		return fmt.Errorf("incache: write snapshot header: %w", err)
+	}
+	if err := gob.NewEncoder(w).Encode(entries); err != nil {
+		return fmt.Errorf("incache: encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadFrom reads a versioned gob stream written by SaveTo and inserts its entries into
+// the cache, giving each one a fresh TTL equal to what remained when it was saved.
+func (c *mcacheCore[K, V]) LoadFrom(r io.Reader) error {
+	if err := readSnapshotHeader(r); err != nil {
+		return err
+	}
+	var entries []persistedEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("incache: decode snapshot: %w", err)
+	}
+	for _, e := range entries {
+		if e.TTL > 0 {
+			c.SetWithTimeout(e.Key, e.Value, e.TTL)
+		} else {
+			c.Set(e.Key, e.Value)
+		}
+	}
+	return nil
+}
+
+// SaveToFile writes a gob snapshot of the cache to path, creating or truncating it.
+func (c *mcacheCore[K, V]) SaveToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("incache: create snapshot file: %w", err)
+	}
+	defer f.Close()
+	return c.SaveTo(f)
+}
+
+// LoadFromFile reads a gob snapshot previously written by SaveToFile and inserts its
+// entries into the cache.
+func (c *mcacheCore[K, V]) LoadFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("incache: open snapshot file: %w", err)
+	}
+	defer f.Close()
+	return c.LoadFrom(f)
+}
+
+// expireIfPast removes k if it has expired, firing a ReasonExpired eviction and recording
+// it in stats. Callers must hold c.mu.
+func (c *mcacheCore[K, V]) expireIfPast(k K) bool {
+	v, ok := c.m[k]
+	if !ok || v.expireAt == nil || !v.expireAt.Before(time.Now()) {
+		return false
+	}
+	delete(c.m, k)
+	c.expQueue.remove(k)
+	c.usedCapacity -= v.weight
+	c.stats.recordEviction(ReasonExpired)
+	if c.events != nil {
+		c.events.fireEviction(k, v.value, ReasonExpired)
+	}
+	return true
+}
+
+// nextExpiry returns the expiry time of the soonest-expiring entry tracked in expQueue,
+// for a SweepHeap janitor to sleep until. It returns false if the cache wasn't built with
+// SweepHeap, or nothing with a TTL is currently queued.
+func (c *mcacheCore[K, V]) nextExpiry() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, expireAt, ok := c.expQueue.peek()
+	return expireAt, ok
+}
+
+// sweepDue removes every entry whose expiry has already passed, driven by a SweepHeap
+// janitor instead of a periodic scan.
+func (c *mcacheCore[K, V]) sweepDue() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
 	for {
-		select {
-		case <-ticker.C:
-			c.mu.Lock()
-			for k, v := range c.m {
-				if v.expireAt != nil && v.expireAt.Before(time.Now()) {
-					delete(c.m, k)
-				}
-			}
-			c.mu.Unlock()
-		case <-c.stopCh:
+		k, expireAt, ok := c.expQueue.peek()
+		if !ok || expireAt.After(now) {
 			return
 		}
+		c.expQueue.pop()
+		v, ok := c.m[k]
+		if !ok {
+			continue
+		}
+		delete(c.m, k)
+		c.usedCapacity -= v.weight
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, v.value, ReasonExpired)
+		}
+	}
+}
+
+// sweepFull removes every currently expired entry, processing at most batchSize entries
+// per lock acquisition so the janitor never holds the write lock for the whole database.
+func (c *mcacheCore[K, V]) sweepFull(batchSize int) {
+	c.mu.RLock()
+	keys := make([]K, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		c.mu.Lock()
+		for _, k := range keys[start:end] {
+			c.expireIfPast(k)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// sweepSampled inspects up to n entries, chosen via Go's randomized map iteration order,
+// and removes the ones that have expired.
+func (c *mcacheCore[K, V]) sweepSampled(n int) (sampled, expired int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.m {
+		if sampled >= n {
+			break
+		}
+		sampled++
+		if c.expireIfPast(k) {
+			expired++
+		}
 	}
+	return
 }
 
 // Purge clears the cache completely.
-func (c *MCache[K, V]) Purge() {
-	if c.timeInterval > 0 {
-		c.stopCh <- struct{}{} // Signal the expiration goroutine to stop
-		close(c.stopCh)
+func (c *mcacheCore[K, V]) Purge() {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+	if c.persistor != nil {
+		c.persistor.stop()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range c.m {
+		c.stats.recordEviction(ReasonPurged)
+		if c.events != nil {
+			c.events.fireEviction(k, v.value, ReasonPurged)
+		}
+	}
+	if c.events != nil {
+		c.events.stop()
 	}
 	c.m = nil
+	c.usedCapacity = 0
+	if c.expQueue != nil {
+		c.expQueue = newExpirationQueue[K]()
+	}
+}
+
+// Count returns the number of non-expired key-value pairs in the database.
+func (c *mcacheCore[K, V]) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var count int
+	for _, v := range c.m {
+		if v.expireAt == nil || !v.expireAt.Before(time.Now()) {
+			count++
+		}
+	}
+	return count
 }
 
-// Count returns the number of key-value pairs in the database.
-func (c *MCache[K, V]) Count() int {
+// Len returns the number of key-value pairs in the database, may include expired entries.
+func (c *mcacheCore[K, V]) Len() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return len(c.m)
 }
 
-func (c *MCache[K, V]) evict(i int) {
+func (c *mcacheCore[K, V]) evict(i int) {
 	var counter int
 	for k, v := range c.m {
-		if counter == i {
+		if counter >= i {
 			break
 		}
 		if v.expireAt != nil && !v.expireAt.After(time.Now()) {
 			delete(c.m, k)
+			c.expQueue.remove(k)
+			c.usedCapacity -= v.weight
 			counter++
+			c.stats.recordEviction(ReasonExpired)
+			if c.events != nil {
+				c.events.fireEviction(k, v.value, ReasonExpired)
+			}
 		}
 	}
-	if i > len(c.m) {
-		i = len(c.m)
-	}
-	for counter < i {
-		for k := range c.m {
-			delete(c.m, k)
-			counter++
+	for k, v := range c.m {
+		if counter >= i {
+			break
+		}
+		delete(c.m, k)
+		c.expQueue.remove(k)
+		c.usedCapacity -= v.weight
+		counter++
+		c.stats.recordEviction(ReasonCapacity)
+		if c.events != nil {
+			c.events.fireEviction(k, v.value, ReasonCapacity)
 		}
 	}
 }
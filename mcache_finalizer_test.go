@@ -0,0 +1,36 @@
+package incache
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestFinalizer_StopsJanitorGoroutine drops the only reference to an MCache without
+// calling Purge, forces a GC, and asserts the finalizer stopped the janitor goroutine.
+// This only works because newManual's finalizer is attached to the outer MCache handle,
+// not to mcacheCore: the janitor goroutine holds a reference to the core, and a finalizer
+// never runs on an object a live goroutine still points to.
+func TestFinalizer_StopsJanitorGoroutine(t *testing.T) {
+	b := New[int, string](10)
+	b.Janitor(time.Millisecond, SweepFull)
+	c := b.Build()
+
+	core := c.(*MCache[int, string]).mcacheCore
+	stopCh := core.janitor.stopCh
+
+	c = nil
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("janitor goroutine did not stop after the cache was collected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
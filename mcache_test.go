@@ -69,7 +69,7 @@ func TestNotFoundSetWithTimeout(t *testing.T) {
 		t.Error("Expected NotFoundSetWithTimeout to return true for a new key with timeout")
 	}
 
-	time.Sleep(c.timeInterval + timeout)
+	time.Sleep(timeout)
 
 	_, ok = c.Get("key2")
 	if ok {
@@ -225,8 +225,8 @@ func TestKeys(t *testing.T) {
 
 func TestPurge(t *testing.T) {
 	c := newManual(&CacheBuilder[string, string]{
-		size:  10,
-		tmIvl: 14,
+		size:            10,
+		janitorInterval: 14,
 	})
 	c.Set("1", "one")
 	c.Set("2", "two")
@@ -235,7 +235,7 @@ func TestPurge(t *testing.T) {
 	c.Purge()
 
 	select {
-	case _, ok := <-c.stopCh:
+	case _, ok := <-c.janitor.stopCh:
 		if ok {
 			t.Errorf("Close: expiration goroutine did not stop as expected")
 		}
@@ -250,8 +250,8 @@ func TestPurge(t *testing.T) {
 
 func TestCount(t *testing.T) {
 	c := newManual[int, string](&CacheBuilder[int, string]{
-		size:  10,
-		tmIvl: time.Millisecond * 200,
+		size:            10,
+		janitorInterval: time.Millisecond * 200,
 	})
 	c.Set(1, "one")
 	c.Set(2, "two")
@@ -307,8 +307,8 @@ func TestLen(t *testing.T) {
 	}
 
 	c = newManual(&CacheBuilder[string, string]{
-		size:  10,
-		tmIvl: time.Millisecond * 150,
+		size:            10,
+		janitorInterval: time.Millisecond * 150,
 	})
 	c.Set("1", "one")
 	c.Set("2", "two")
@@ -0,0 +1,115 @@
+package incache
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// snapshotMagic identifies the first bytes of every snapshot this package writes, gob or
+// JSON, so LoadFrom/LoadFromJSON can reject a file that isn't one of ours before attempting
+// to decode it. snapshotVersion is bumped whenever the entry format changes incompatibly,
+// so a future policy (e.g. one needing extra per-entry fields) can tell an old snapshot
+// apart from a new one instead of failing decode with a confusing error.
+const (
+	snapshotMagic           = "ICSN"
+	snapshotVersion  uint16 = 1
+	snapshotMagicLen        = len(snapshotMagic)
+)
+
+// writeSnapshotHeader writes the magic bytes and current version to w, ahead of the
+// encoded entries.
+func writeSnapshotHeader(w io.Writer) error {
+	if _, err := io.WriteString(w, snapshotMagic); err != nil {
+		return err
+	}
+	version := [2]byte{byte(snapshotVersion >> 8), byte(snapshotVersion)}
+	_, err := w.Write(version[:])
+	return err
+}
+
+// readSnapshotHeader reads and validates the magic bytes and version written by
+// writeSnapshotHeader, returning an error if they're missing or the version is one this
+// build doesn't know how to decode.
+func readSnapshotHeader(r io.Reader) error {
+	header := make([]byte, snapshotMagicLen+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("incache: read snapshot header: %w", err)
+	}
+	if string(header[:snapshotMagicLen]) != snapshotMagic {
+		return fmt.Errorf("incache: not an incache snapshot")
+	}
+	version := uint16(header[snapshotMagicLen])<<8 | uint16(header[snapshotMagicLen+1])
+	if version != snapshotVersion {
+		return fmt.Errorf("incache: unsupported snapshot version %d", version)
+	}
+	return nil
+}
+
+// persistedEntry is the on-disk representation of one cache entry. TTL is stored as the
+// duration remaining at the moment of the snapshot rather than an absolute time, so
+// LoadFrom can rehydrate entries with a correct expiration relative to when it runs, not
+// to when SaveTo ran. TTL of 0 means the entry has no expiration.
+type persistedEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+}
+
+// persistedLFUEntry extends persistedEntry with the entry's access-frequency counter, so
+// an LFU snapshot can restore a key's standing relative to its neighbors instead of every
+// entry coming back with a cold, identical frequency.
+type persistedLFUEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+	Freq  uint
+}
+
+// persistableToFile is implemented by caches that WithPersistence can periodically snapshot.
+type persistableToFile interface {
+	SaveToFile(path string) error
+}
+
+// persistor periodically snapshots a cache to a file on a background goroutine. It is
+// shared by MCache, LRUCache, and LFUCache, configured via CacheBuilder.WithPersistence, mirroring
+// how janitor is shared for expiration sweeps.
+type persistor struct {
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// startPersistor launches a persistor that saves target to path every interval. It
+// returns nil if interval is not positive or path is empty, leaving the cache to be
+// saved only via explicit SaveToFile/SaveTo calls.
+func startPersistor(interval time.Duration, path string, target persistableToFile) *persistor {
+	if interval <= 0 || path == "" {
+		return nil
+	}
+	p := &persistor{stopCh: make(chan struct{})}
+	go p.run(interval, path, target)
+	return p
+}
+
+func (p *persistor) run(interval time.Duration, path string, target persistableToFile) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Best-effort: a background tick has nowhere to surface a write error.
+			// Callers who need to observe failures should call SaveToFile directly.
+			_ = target.SaveToFile(path)
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// stop signals the persistor's goroutine to exit. Safe to call more than once.
+func (p *persistor) stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+}
@@ -0,0 +1,177 @@
+package incache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadFrom_MCache(t *testing.T) {
+	c := newManual(&CacheBuilder[string, string]{size: 10})
+	c.Set("key1", "value1")
+	c.SetWithTimeout("key2", "value2", time.Minute)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo failed: %v", err)
+	}
+
+	c2 := newManual(&CacheBuilder[string, string]{size: 10})
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+
+	if v, ok := c2.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to be restored, got (%q, %v)", v, ok)
+	}
+	if v, ok := c2.Get("key2"); !ok || v != "value2" {
+		t.Errorf("expected key2 to be restored, got (%q, %v)", v, ok)
+	}
+}
+
+func TestSaveLoadFrom_ExpiredEntriesAreNotSaved(t *testing.T) {
+	c := newManual(&CacheBuilder[string, string]{size: 10})
+	c.SetWithTimeout("key1", "value1", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo failed: %v", err)
+	}
+
+	c2 := newManual(&CacheBuilder[string, string]{size: 10})
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+
+	if _, ok := c2.Get("key1"); ok {
+		t.Errorf("expected expired entry not to be persisted")
+	}
+}
+
+func TestSaveToFileLoadFromFile_LRU(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+
+	c := NewLRU[string, string](10)
+	c.Set("key1", "value1")
+	c.SetWithTimeout("key2", "value2", time.Minute)
+
+	if err := c.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	c2 := NewLRU[string, string](10)
+	if err := c2.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if v, ok := c2.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to be restored, got (%q, %v)", v, ok)
+	}
+	if v, ok := c2.Get("key2"); !ok || v != "value2" {
+		t.Errorf("expected key2 to be restored, got (%q, %v)", v, ok)
+	}
+}
+
+func TestSaveLoadFrom_LFU_RestoresFrequency(t *testing.T) {
+	c := NewLFU[string, string](10)
+	c.Set("key1", "value1")
+	c.Get("key1")
+	c.Get("key1")
+	c.SetWithTimeout("key2", "value2", time.Minute)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo failed: %v", err)
+	}
+
+	c2 := NewLFU[string, string](10)
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+
+	if v, ok := c2.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to be restored, got (%q, %v)", v, ok)
+	}
+	if v, ok := c2.Get("key2"); !ok || v != "value2" {
+		t.Errorf("expected key2 to be restored, got (%q, %v)", v, ok)
+	}
+
+	elem := c2.m["key1"]
+	if got := elem.Value.(*lfuItem[string, string]).freq; got < 3 {
+		t.Errorf("expected key1's frequency counter to carry over, got %d", got)
+	}
+}
+
+func TestSaveLoadFromJSON_LFU(t *testing.T) {
+	c := NewLFU[string, string](10)
+	c.Set("key1", "value1")
+	c.SetWithTimeout("key2", "value2", time.Minute)
+
+	var buf bytes.Buffer
+	if err := c.SaveToJSON(&buf); err != nil {
+		t.Fatalf("SaveToJSON failed: %v", err)
+	}
+
+	c2 := NewLFU[string, string](10)
+	if err := c2.LoadFromJSON(&buf); err != nil {
+		t.Fatalf("LoadFromJSON failed: %v", err)
+	}
+
+	if v, ok := c2.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to be restored, got (%q, %v)", v, ok)
+	}
+	if v, ok := c2.Get("key2"); !ok || v != "value2" {
+		t.Errorf("expected key2 to be restored, got (%q, %v)", v, ok)
+	}
+}
+
+func TestLoadFrom_RejectsUnversionedData(t *testing.T) {
+	c := NewLRU[string, string](10)
+	if err := c.LoadFrom(bytes.NewReader([]byte("not a snapshot"))); err == nil {
+		t.Errorf("expected LoadFrom to reject a stream without a valid snapshot header")
+	}
+}
+
+func TestLoadFromFile_MissingFile(t *testing.T) {
+	c := NewLRU[string, string](10)
+	if err := c.LoadFromFile(filepath.Join(t.TempDir(), "does-not-exist.gob")); err == nil {
+		t.Errorf("expected an error loading a missing snapshot file")
+	}
+}
+
+func TestWithPersistence_LoadsOnBuildAndSavesInBackground(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+
+	seed := NewLRU[string, string](10)
+	seed.Set("key1", "value1")
+	if err := seed.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	b := New[string, string](10)
+	b.EvictType(LRU)
+	b.WithPersistence(path, 10*time.Millisecond)
+	c := b.Build()
+	defer c.Purge()
+
+	if v, ok := c.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected Build to load the existing snapshot, got (%q, %v)", v, ok)
+	}
+
+	c.Set("key2", "value2")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		data, err := os.ReadFile(path)
+		if err == nil && bytes.Contains(data, []byte("key2")) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the background persistor to snapshot key2 to disk")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
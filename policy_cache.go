@@ -0,0 +1,603 @@
+package incache
+
+import (
+	"sync"
+	"time"
+)
+
+type policyCacheItem[V any] struct {
+	value    V
+	expireAt *time.Time
+	weight   uint64
+}
+
+// PolicyCache is a Cache[K,V] implementation whose eviction order is delegated entirely
+// to a pluggable policy, rather than being hard-coded the way LRUCache, LFUCache, and
+// FIFOCache each are. It is built via CacheBuilder.EvictType(Policy) combined with
+// CacheBuilder.EvictionPolicy, or directly via NewPolicyCache/NewPolicyCacheWithPolicy.
+// It does not support persistence or WithExpiryJitter; MCache and LRUCache support both,
+// and LFUCache supports persistence only.
+type PolicyCache[K comparable, V any] struct {
+	mu           sync.RWMutex
+	size         uint
+	m            map[K]*policyCacheItem[V]
+	policyKind   EvictionPolicy
+	policy       policy[K]
+	loader       func(K) (V, time.Duration, error)
+	loaderGrp    loaderGroup[K, V]
+	events       *eventDispatcher[K, V]
+	weigher      func(K, V) uint64
+	usedCapacity uint64
+	stats        statsCounters
+	janitor      *janitor
+	negCache     *negativeCache[K]
+	expQueue     *expirationQueue[K]
+}
+
+// NewPolicyCache builds a PolicyCache of the given size using PolicyLRU.
+func NewPolicyCache[K comparable, V any](size uint) *PolicyCache[K, V] {
+	return NewPolicyCacheWithPolicy[K, V](size, PolicyLRU)
+}
+
+// NewPolicyCacheWithPolicy builds a PolicyCache of the given size, evicting according to p.
+func NewPolicyCacheWithPolicy[K comparable, V any](size uint, p EvictionPolicy) *PolicyCache[K, V] {
+	return &PolicyCache[K, V]{
+		size:       size,
+		m:          make(map[K]*policyCacheItem[V]),
+		policyKind: p,
+		policy:     newPolicy[K](p, size),
+		weigher:    defaultWeigher[K, V],
+	}
+}
+
+// newPolicyCache builds a PolicyCache from a CacheBuilder, letting it be produced via
+// New[K,V](size).EvictType(Policy).EvictionPolicy(...).Build().
+func newPolicyCache[K comparable, V any](cacheBuilder *CacheBuilder[K, V]) *PolicyCache[K, V] {
+	c := NewPolicyCacheWithPolicy[K, V](cacheBuilder.size, cacheBuilder.evictionPolicy)
+	c.loader = cacheBuilder.loader
+	c.weigher = resolveWeigher[K, V](cacheBuilder.weigher)
+	c.negCache = newNegativeCache[K](cacheBuilder.negativeTTL)
+	if cacheBuilder.janitorStrategy == SweepHeap {
+		c.expQueue = newExpirationQueue[K]()
+	}
+	c.janitor = startJanitor(cacheBuilder.janitorInterval, cacheBuilder.janitorStrategy, c)
+	if cacheBuilder.onInsert != nil || cacheBuilder.onEvict != nil {
+		c.events = newEventDispatcher[K, V]()
+		if cacheBuilder.onInsert != nil {
+			c.events.onInsertion(cacheBuilder.onInsert)
+		}
+		if cacheBuilder.onEvict != nil {
+			c.events.onEviction(cacheBuilder.onEvict)
+		}
+	}
+	return c
+}
+
+// OnInsertion registers fn to be called, on a background goroutine, whenever a key-value
+// pair is inserted into the cache. It returns a listener id usable with RemoveInsertionListener.
+func (c *PolicyCache[K, V]) OnInsertion(fn func(K, V)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onInsertion(fn)
+}
+
+// RemoveInsertionListener unregisters a listener previously added with OnInsertion.
+func (c *PolicyCache[K, V]) RemoveInsertionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeInsertionListener(id)
+	}
+}
+
+// OnEviction registers fn to be called, on a background goroutine, whenever a key-value
+// pair leaves the cache. It returns a listener id usable with RemoveEvictionListener.
+func (c *PolicyCache[K, V]) OnEviction(fn func(K, V, EvictionReason)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onEviction(fn)
+}
+
+// RemoveEvictionListener unregisters a listener previously added with OnEviction.
+func (c *PolicyCache[K, V]) RemoveEvictionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeEvictionListener(id)
+	}
+}
+
+// Get retrieves the value associated with the given key from the cache.
+// If the key is not found or has expired, it returns (zero value of V, false).
+// Otherwise, it returns (value, true).
+func (c *PolicyCache[K, V]) Get(k K) (v V, b bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.m[k]
+	if !ok {
+		c.stats.misses.Add(1)
+		return
+	}
+
+	if item.expireAt != nil && item.expireAt.Before(time.Now()) {
+		c.removeKey(k, item)
+		c.stats.misses.Add(1)
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonExpired)
+		}
+		return
+	}
+
+	c.policy.OnAccess(k)
+	c.stats.hits.Add(1)
+
+	return item.value, true
+}
+
+// removeKey deletes k from the value map and stops tracking it in the policy and the
+// expiration queue, without touching stats or firing events. Callers must hold c.mu and
+// already have item (c.m[k]).
+func (c *PolicyCache[K, V]) removeKey(k K, item *policyCacheItem[V]) {
+	delete(c.m, k)
+	c.policy.Remove(k)
+	c.usedCapacity -= item.weight
+	c.expQueue.remove(k)
+}
+
+// GetAll retrieves all key-value pairs from the cache.
+// It returns a map containing all the key-value pairs that are not expired.
+func (c *PolicyCache[K, V]) GetAll() map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := make(map[K]V)
+	for k, item := range c.m {
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			m[k] = item.value
+		}
+	}
+
+	return m
+}
+
+// Set adds the key-value pair to the cache.
+func (c *PolicyCache[K, V]) Set(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(k, v, 0)
+}
+
+// SetWithTimeout adds the key-value pair to the cache with a specified expiration time.
+func (c *PolicyCache[K, V]) SetWithTimeout(k K, v V, t time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(k, v, t)
+}
+
+// NotFoundSet adds the key-value pair to the cache only if the key does not exist.
+// It returns true if the key was added to the cache, otherwise false.
+func (c *PolicyCache[K, V]) NotFoundSet(k K, v V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.m[k]; ok {
+		return false
+	}
+
+	c.set(k, v, 0)
+	return true
+}
+
+// NotFoundSetWithTimeout adds the key-value pair to the cache only if the key does not exist.
+// It sets an expiration time for the key-value pair.
+// It returns true if the key was added to the cache, otherwise false.
+func (c *PolicyCache[K, V]) NotFoundSetWithTimeout(k K, v V, t time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.m[k]; ok {
+		return false
+	}
+
+	c.set(k, v, t)
+	return true
+}
+
+// GetOrLoad retrieves the value associated with the given key, invoking the configured
+// loader on a miss. Concurrent calls for the same key coalesce into a single loader invocation.
+// It returns ErrNoLoader if no loader was configured via CacheBuilder.Loader.
+func (c *PolicyCache[K, V]) GetOrLoad(k K) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	if err, ok := c.negCache.get(k); ok {
+		var zero V
+		return zero, err
+	}
+	if c.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	var ttl time.Duration
+	var loaded bool
+	return c.loaderGrp.do(k, func() (V, error) {
+		if v, ok := c.Get(k); ok {
+			return v, nil
+		}
+		if err, ok := c.negCache.get(k); ok {
+			var zero V
+			return zero, err
+		}
+		start := time.Now()
+		v, d, err := c.loader(k)
+		c.stats.recordLoad(time.Since(start), err)
+		ttl = d
+		loaded = true
+		return v, err
+	}, func(v V, err error, fresh bool) {
+		if !loaded || !fresh {
+			return
+		}
+		if err != nil {
+			c.negCache.set(k, err)
+			return
+		}
+		c.negCache.clear(k)
+		c.SetWithTimeout(k, v, ttl)
+	})
+}
+
+// Delete removes the key-value pair associated with the given key from the cache.
+func (c *PolicyCache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.delete(k)
+}
+
+func (c *PolicyCache[K, V]) delete(k K) {
+	c.loaderGrp.bump(k)
+	c.negCache.clear(k)
+	item, ok := c.m[k]
+	if !ok {
+		return
+	}
+
+	c.removeKey(k, item)
+	c.stats.recordEviction(ReasonDeleted)
+	if c.events != nil {
+		c.events.fireEviction(k, item.value, ReasonDeleted)
+	}
+}
+
+// TransferTo transfers all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *PolicyCache[K, V]) TransferTo(dst Cache[K, V]) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	for k, item := range src.m {
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
+			src.stats.recordEviction(ReasonTransferred)
+			if src.events != nil {
+				src.events.fireEviction(k, item.value, ReasonTransferred)
+			}
+		}
+	}
+	src.m = make(map[K]*policyCacheItem[V])
+	src.policy = newPolicy[K](src.policyKind, src.size)
+	src.usedCapacity = 0
+	if src.expQueue != nil {
+		src.expQueue = newExpirationQueue[K]()
+	}
+}
+
+// CopyTo copies all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *PolicyCache[K, V]) CopyTo(dst Cache[K, V]) {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	for k, item := range src.m {
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
+		}
+	}
+}
+
+// setValueWithTimeout inserts a value with an absolute expiration time, used internally by TransferTo/CopyTo
+// to move entries between cache implementations without losing their remaining TTL.
+func (c *PolicyCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaderGrp.bump(k)
+	weight := c.weigher(k, v.value)
+
+	if old, ok := c.m[k]; ok {
+		c.removeKey(k, old)
+	}
+
+	c.m[k] = &policyCacheItem[V]{value: v.value, expireAt: v.expireAt, weight: weight}
+	c.policy.OnInsert(k)
+	c.usedCapacity += weight
+	c.evictToFit()
+
+	if v.expireAt != nil {
+		c.expQueue.push(k, *v.expireAt)
+	} else {
+		c.expQueue.remove(k)
+	}
+	c.janitor.wake()
+}
+
+// Keys returns a slice of all keys currently stored in the cache.
+// The returned slice does not include expired keys.
+// The order of keys in the slice is not guaranteed.
+func (c *PolicyCache[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]K, 0, len(c.m))
+	for k, item := range c.m {
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Purge removes all key-value pairs from the cache.
+func (c *PolicyCache[K, V]) Purge() {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, item := range c.m {
+		c.stats.recordEviction(ReasonPurged)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonPurged)
+		}
+	}
+	if c.events != nil {
+		c.events.stop()
+	}
+
+	c.m = make(map[K]*policyCacheItem[V])
+	c.policy = newPolicy[K](c.policyKind, c.size)
+	c.usedCapacity = 0
+	if c.expQueue != nil {
+		c.expQueue = newExpirationQueue[K]()
+	}
+}
+
+// Count returns the number of non-expired key-value pairs currently stored in the cache.
+func (c *PolicyCache[K, V]) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var count int
+	for _, item := range c.m {
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Len returns the number of elements in the cache.
+func (c *PolicyCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.m)
+}
+
+// evictToFit evicts entries until usedCapacity no longer exceeds the cache's capacity, or
+// only one entry is left (matching every other Cache[K,V] implementation, which lets a
+// single entry whose own weight exceeds the whole capacity stand via Set, rejecting it
+// only via TrySet). That entry is normally the just-inserted one, but a policy such as
+// PolicyRandom can pick the new key as a victim and leave another in its place. Unlike the other implementations, it runs after the new
+// entry has already been inserted and counted against usedCapacity, not before: several
+// policies (PolicyTinyLFU in particular) decide what to evict based on state that only
+// exists once the new key is already tracked. Callers must hold c.mu.
+func (c *PolicyCache[K, V]) evictToFit() {
+	for c.usedCapacity > uint64(c.size) && len(c.m) > 1 {
+		c.evict(1)
+	}
+}
+
+// expireIfPast removes k if it has expired, firing a ReasonExpired eviction and recording
+// it in stats. Callers must hold c.mu.
+func (c *PolicyCache[K, V]) expireIfPast(k K) bool {
+	item, ok := c.m[k]
+	if !ok {
+		return false
+	}
+	if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+		return false
+	}
+	c.removeKey(k, item)
+	c.stats.recordEviction(ReasonExpired)
+	if c.events != nil {
+		c.events.fireEviction(k, item.value, ReasonExpired)
+	}
+	return true
+}
+
+// nextExpiry returns the expiry time of the soonest-expiring entry still tracked in the
+// cache's expirationQueue, for janitor.runHeap. Only meaningful when built with SweepHeap.
+func (c *PolicyCache[K, V]) nextExpiry() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, expireAt, ok := c.expQueue.peek()
+	return expireAt, ok
+}
+
+// sweepDue removes every entry whose expiry has already passed, for janitor.runHeap.
+func (c *PolicyCache[K, V]) sweepDue() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for {
+		k, expireAt, ok := c.expQueue.peek()
+		if !ok || expireAt.After(now) {
+			return
+		}
+		c.expQueue.pop()
+		item, ok := c.m[k]
+		if !ok {
+			continue
+		}
+		delete(c.m, k)
+		c.policy.Remove(k)
+		c.usedCapacity -= item.weight
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonExpired)
+		}
+	}
+}
+
+// sweepFull removes every currently expired entry, processing at most batchSize entries
+// per lock acquisition so the janitor never holds the write lock for the whole cache.
+func (c *PolicyCache[K, V]) sweepFull(batchSize int) {
+	c.mu.RLock()
+	keys := make([]K, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		c.mu.Lock()
+		for _, k := range keys[start:end] {
+			c.expireIfPast(k)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// sweepSampled inspects up to n entries, chosen via Go's randomized map iteration order,
+// and removes the ones that have expired.
+func (c *PolicyCache[K, V]) sweepSampled(n int) (sampled, expired int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.m {
+		if sampled >= n {
+			break
+		}
+		sampled++
+		if c.expireIfPast(k) {
+			expired++
+		}
+	}
+	return
+}
+
+func (c *PolicyCache[K, V]) set(k K, v V, exp time.Duration) {
+	c.loaderGrp.bump(k)
+	var tm *time.Time
+	if exp > 0 {
+		t := time.Now().Add(exp)
+		tm = &t
+	}
+	weight := c.weigher(k, v)
+
+	if old, ok := c.m[k]; ok {
+		c.removeKey(k, old)
+		c.stats.recordEviction(ReasonDeleted)
+		if c.events != nil {
+			c.events.fireEviction(k, old.value, ReasonDeleted)
+		}
+	}
+
+	c.m[k] = &policyCacheItem[V]{value: v, expireAt: tm, weight: weight}
+	c.policy.OnInsert(k)
+	c.usedCapacity += weight
+	c.evictToFit()
+	c.stats.insertions.Add(1)
+	if tm != nil {
+		c.expQueue.push(k, *tm)
+		c.janitor.wake()
+	} else {
+		c.expQueue.remove(k)
+	}
+
+	if c.events != nil {
+		c.events.fireInsertion(k, v)
+	}
+}
+
+// TrySet adds or updates the key-value pair in the cache, like Set, but rejects the entry
+// with ErrWeightExceedsCapacity if its weight alone exceeds the cache's capacity.
+func (c *PolicyCache[K, V]) TrySet(k K, v V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.weigher(k, v) > uint64(c.size) {
+		return ErrWeightExceedsCapacity
+	}
+
+	c.set(k, v, 0)
+	return nil
+}
+
+// Used returns the total weight of all entries currently stored in the cache.
+func (c *PolicyCache[K, V]) Used() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usedCapacity
+}
+
+// Capacity returns the cache's capacity in weight units, as configured by New.
+func (c *PolicyCache[K, V]) Capacity() uint64 {
+	return uint64(c.size)
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction/load counters.
+func (c *PolicyCache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the cache's hit/miss/eviction/load counters.
+func (c *PolicyCache[K, V]) ResetStats() {
+	c.stats.reset()
+}
+
+func (c *PolicyCache[K, V]) evict(n int) {
+	for _, key := range c.policy.Evict(n) {
+		item, ok := c.m[key]
+		if !ok {
+			continue
+		}
+		delete(c.m, key)
+		c.usedCapacity -= item.weight
+		c.expQueue.remove(key)
+		c.stats.recordEviction(ReasonCapacity)
+		if c.events != nil {
+			c.events.fireEviction(key, item.value, ReasonCapacity)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package incache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSet_PolicyCache(t *testing.T) {
+	c := NewPolicyCache[string, string](10)
+
+	c.Set("key1", "value1")
+	if c.m["key1"].value != "value1" {
+		t.Errorf("Set failed")
+	}
+}
+
+func TestGet_PolicyCache(t *testing.T) {
+	c := NewPolicyCache[string, string](10)
+
+	c.Set("key1", "value1")
+	if v, ok := c.Get("key1"); !ok || v != "value1" {
+		t.Errorf("Get failed")
+	}
+}
+
+func TestSetWithTimeout_PolicyCache(t *testing.T) {
+	c := NewPolicyCache[string, string](10)
+
+	c.SetWithTimeout("key1", "value1", time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("expected expired entry to be gone")
+	}
+}
+
+func TestNotFoundSet_PolicyCache(t *testing.T) {
+	c := NewPolicyCache[string, string](10)
+
+	if !c.NotFoundSet("key1", "value1") {
+		t.Errorf("NotFoundSet failed")
+	}
+	if c.NotFoundSet("key1", "value2") {
+		t.Errorf("NotFoundSet failed")
+	}
+}
+
+func TestDelete_PolicyCache(t *testing.T) {
+	c := NewPolicyCache[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Delete("key1")
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("Delete failed")
+	}
+}
+
+func TestEviction_PolicyCache_LRU(t *testing.T) {
+	c := NewPolicyCacheWithPolicy[int, string](2, PolicyLRU)
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	c.Get(1) // 1 is now more recently used than 2
+	c.Set(3, "three")
+
+	if _, ok := c.Get(2); ok {
+		t.Errorf("expected least-recently-used key 2 to be evicted")
+	}
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("expected recently accessed key 1 to survive")
+	}
+}
+
+func TestEviction_PolicyCache_FIFO(t *testing.T) {
+	c := NewPolicyCacheWithPolicy[int, string](2, PolicyFIFO)
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	c.Get(1) // access order does not matter for FIFO
+	c.Set(3, "three")
+
+	if _, ok := c.Get(1); ok {
+		t.Errorf("expected oldest-inserted key 1 to be evicted")
+	}
+	if _, ok := c.Get(2); !ok {
+		t.Errorf("expected key 2 to survive")
+	}
+}
+
+func TestEviction_PolicyCache_Random(t *testing.T) {
+	c := NewPolicyCacheWithPolicy[int, string](2, PolicyRandom)
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	c.Set(3, "three")
+
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2 after exceeding capacity", c.Len())
+	}
+}
+
+func TestEviction_PolicyCache_LFU(t *testing.T) {
+	c := NewPolicyCacheWithPolicy[int, string](2, PolicyLFU)
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	c.Get(1)
+	c.Get(1)
+	c.Set(3, "three") // 2 has the lowest access frequency
+
+	if _, ok := c.Get(2); ok {
+		t.Errorf("expected least-frequently-used key 2 to be evicted")
+	}
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("expected frequently accessed key 1 to survive")
+	}
+}
+
+// TestFrequentKeysSurviveScan_PolicyTinyLFU is the defining property of W-TinyLFU, exercised
+// through PolicyCache instead of the dedicated TinyLFUCache: a handful of frequently-accessed
+// "hot" keys must survive a large burst of one-off "scan" keys that would flush them out of
+// a plain LRU.
+func TestFrequentKeysSurviveScan_PolicyTinyLFU(t *testing.T) {
+	c := NewPolicyCacheWithPolicy[string, string](150, PolicyTinyLFU)
+
+	const keepCount = 5
+	for i := 0; i < keepCount; i++ {
+		c.Set(fmt.Sprintf("keep%d", i), "hot")
+	}
+	// PolicyCache drains the window into main only once capacity is exceeded, so this key and
+	// every "keep" key stay in the window until the fillers below push them into probation.
+	c.Set("flush-window", "x")
+
+	for i := 0; i < keepCount; i++ {
+		key := fmt.Sprintf("keep%d", i)
+		for j := 0; j < 3; j++ {
+			if _, ok := c.Get(key); !ok {
+				t.Fatalf("expected %s to still be present while warming it up", key)
+			}
+		}
+	}
+
+	// Fill main to capacity with filler keys so later scan keys must contend for admission.
+	for i := 0; i < 300; i++ {
+		c.Set(fmt.Sprintf("filler%d", i), "f")
+	}
+
+	// A large one-off scan: each key is inserted once and never read again.
+	for i := 0; i < 300; i++ {
+		c.Set(fmt.Sprintf("scan%d", i), "s")
+	}
+
+	for i := 0; i < keepCount; i++ {
+		key := fmt.Sprintf("keep%d", i)
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("expected frequently-accessed %s to survive the scan, but it was evicted", key)
+		}
+	}
+}
+
+func TestWithPolicy_BuildsPolicyCache(t *testing.T) {
+	built := New[int, string](2).WithPolicy(PolicyTinyLFU).Build()
+
+	if _, ok := built.(*PolicyCache[int, string]); !ok {
+		t.Errorf("Build() with WithPolicy(...) = %T, want *PolicyCache", built)
+	}
+}
+
+func TestBuild_EvictTypePolicy(t *testing.T) {
+	b := New[int, string](2)
+	b.EvictType(Policy)
+	b.EvictionPolicy(PolicyFIFO)
+	built := b.Build()
+
+	if _, ok := built.(*PolicyCache[int, string]); !ok {
+		t.Errorf("Build() with EvictType(Policy) = %T, want *PolicyCache", built)
+	}
+}
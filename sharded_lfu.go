@@ -0,0 +1,137 @@
+package incache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"hash/maphash"
+	"time"
+)
+
+// Hasher computes a shard-routing hash for a key of type K. A good Hasher distributes
+// keys roughly uniformly across shards, so no single shard's lock becomes a bottleneck
+// under concurrent access.
+type Hasher[K comparable] func(K) uint64
+
+// defaultHasher hashes string keys with maphash (fast and collision-resistant without
+// pulling in a key-specific algorithm), and falls back to an fnv-1a hash of the key's
+// %v representation for everything else, mirroring countMinSketch.hash.
+func defaultHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(k K) uint64 {
+		if s, ok := any(k).(string); ok {
+			return maphash.String(seed, s)
+		}
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%v", k)
+		return h.Sum64()
+	}
+}
+
+// ShardedLFU spreads its entries across a fixed number of independently locked LFUCache
+// shards, so concurrent callers hashing to different shards don't contend on the same
+// sync.RWMutex. This is the standard scaling pattern used by bigcache and ccache: a
+// single-lock cache's throughput plateaus once contention on that one lock dominates, and
+// splitting the keyspace across N locks raises that ceiling roughly N-fold at the cost of
+// capacity and ordering only being enforced per-shard rather than globally.
+type ShardedLFU[K comparable, V any] struct {
+	shards []*LFUCache[K, V]
+	hasher Hasher[K]
+}
+
+// NewShardedLFU builds a ShardedLFU with shardCount shards, each an LFUCache sized to
+// totalCap/shardCount, routing keys with the default Hasher. shardCount is clamped to at
+// least 1.
+func NewShardedLFU[K comparable, V any](totalCap int, shardCount int) *ShardedLFU[K, V] {
+	return NewShardedLFUWithHasher[K, V](totalCap, shardCount, defaultHasher[K]())
+}
+
+// NewShardedLFUWithHasher is like NewShardedLFU but lets the caller supply the Hasher
+// used to route keys to shards, instead of the default string/fnv hasher.
+func NewShardedLFUWithHasher[K comparable, V any](totalCap int, shardCount int, hasher Hasher[K]) *ShardedLFU[K, V] {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+	perShard := uint(totalCap / shardCount)
+	if perShard == 0 {
+		perShard = 1
+	}
+	shards := make([]*LFUCache[K, V], shardCount)
+	for i := range shards {
+		shards[i] = NewLFU[K, V](perShard)
+	}
+	return &ShardedLFU[K, V]{shards: shards, hasher: hasher}
+}
+
+// shardFor returns the shard responsible for key.
+func (s *ShardedLFU[K, V]) shardFor(key K) *LFUCache[K, V] {
+	return s.shards[s.hasher(key)%uint64(len(s.shards))]
+}
+
+// Get retrieves the value associated with key from its shard.
+func (s *ShardedLFU[K, V]) Get(key K) (V, bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Set adds or updates key's value in its shard without an expiration time.
+func (s *ShardedLFU[K, V]) Set(key K, value V) {
+	s.shardFor(key).Set(key, value)
+}
+
+// SetWithTimeout adds or updates key's value in its shard with an expiration time.
+func (s *ShardedLFU[K, V]) SetWithTimeout(key K, value V, exp time.Duration) {
+	s.shardFor(key).SetWithTimeout(key, value, exp)
+}
+
+// NotFoundSet adds key's value to its shard if key does not already exist there, and
+// returns true. Otherwise, it does nothing and returns false.
+func (s *ShardedLFU[K, V]) NotFoundSet(key K, value V) bool {
+	return s.shardFor(key).NotFoundSet(key, value)
+}
+
+// Delete removes key from its shard.
+func (s *ShardedLFU[K, V]) Delete(key K) {
+	s.shardFor(key).Delete(key)
+}
+
+// Purge clears every shard.
+func (s *ShardedLFU[K, V]) Purge() {
+	for _, shard := range s.shards {
+		shard.Purge()
+	}
+}
+
+// Keys returns the keys of every shard's entries, in arbitrary order.
+func (s *ShardedLFU[K, V]) Keys() []K {
+	var keys []K
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// GetAll returns all key-value pairs across every shard.
+func (s *ShardedLFU[K, V]) GetAll() map[K]V {
+	all := make(map[K]V)
+	for _, shard := range s.shards {
+		for k, v := range shard.GetAll() {
+			all[k] = v
+		}
+	}
+	return all
+}
+
+// TransferTo transfers every shard's entries to dst, the same way LFUCache.TransferTo
+// does for a single cache.
+func (s *ShardedLFU[K, V]) TransferTo(dst Cache[K, V]) {
+	for _, shard := range s.shards {
+		shard.TransferTo(dst)
+	}
+}
+
+// CopyTo copies every shard's entries to dst, the same way LFUCache.CopyTo does for a
+// single cache.
+func (s *ShardedLFU[K, V]) CopyTo(dst Cache[K, V]) {
+	for _, shard := range s.shards {
+		shard.CopyTo(dst)
+	}
+}
@@ -0,0 +1,176 @@
+package incache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedLFU_SetGet(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	s.Set("key1", "value1")
+	s.Set("key2", "value2")
+
+	if v, ok := s.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to be present, got (%q, %v)", v, ok)
+	}
+	if v, ok := s.Get("key2"); !ok || v != "value2" {
+		t.Errorf("expected key2 to be present, got (%q, %v)", v, ok)
+	}
+	if _, ok := s.Get("missing"); ok {
+		t.Errorf("expected missing key to be absent")
+	}
+}
+
+func TestShardedLFU_SetWithTimeout(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	s.SetWithTimeout("key1", "value1", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := s.Get("key1"); ok {
+		t.Errorf("expected key1 to have expired")
+	}
+}
+
+func TestShardedLFU_NotFoundSet(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	if !s.NotFoundSet("key1", "value1") {
+		t.Errorf("expected NotFoundSet to succeed on a new key")
+	}
+	if s.NotFoundSet("key1", "value2") {
+		t.Errorf("expected NotFoundSet to fail on an existing key")
+	}
+	if v, _ := s.Get("key1"); v != "value1" {
+		t.Errorf("expected key1 to keep its original value, got %q", v)
+	}
+}
+
+func TestShardedLFU_Delete(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	s.Set("key1", "value1")
+	s.Delete("key1")
+
+	if _, ok := s.Get("key1"); ok {
+		t.Errorf("expected key1 to be deleted")
+	}
+}
+
+func TestShardedLFU_KeysAndGetAllAggregateAcrossShards(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	want := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		k, v := fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i)
+		s.Set(k, v)
+		want[k] = v
+	}
+
+	keys := s.Keys()
+	if len(keys) != len(want) {
+		t.Fatalf("expected %d keys across all shards, got %d", len(want), len(keys))
+	}
+
+	all := s.GetAll()
+	if len(all) != len(want) {
+		t.Fatalf("expected %d entries across all shards, got %d", len(want), len(all))
+	}
+	for k, v := range want {
+		if all[k] != v {
+			t.Errorf("expected %s to equal %q, got %q", k, v, all[k])
+		}
+	}
+}
+
+func TestShardedLFU_Purge(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+
+	for i := 0; i < 20; i++ {
+		s.Set(fmt.Sprintf("key%d", i), "value")
+	}
+	s.Purge()
+
+	if keys := s.Keys(); len(keys) != 0 {
+		t.Errorf("expected no keys after Purge, got %d", len(keys))
+	}
+}
+
+func TestShardedLFU_TransferTo(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+	s.Set("key1", "value1")
+	s.Set("key2", "value2")
+
+	dst := New[string, string](100).Build()
+	s.TransferTo(dst)
+
+	if v, ok := dst.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to have been transferred, got (%q, %v)", v, ok)
+	}
+	if keys := s.Keys(); len(keys) != 0 {
+		t.Errorf("expected the sharded cache to be empty after TransferTo, got %d keys", len(keys))
+	}
+}
+
+func TestShardedLFU_CopyTo(t *testing.T) {
+	s := NewShardedLFU[string, string](100, 4)
+	s.Set("key1", "value1")
+
+	dst := New[string, string](100).Build()
+	s.CopyTo(dst)
+
+	if v, ok := dst.Get("key1"); !ok || v != "value1" {
+		t.Errorf("expected key1 to have been copied, got (%q, %v)", v, ok)
+	}
+	if _, ok := s.Get("key1"); !ok {
+		t.Errorf("expected the sharded cache to still have key1 after CopyTo")
+	}
+}
+
+func TestShardedLFU_ConcurrentAccess(t *testing.T) {
+	s := NewShardedLFU[int, int](1000, 8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Set(i, i)
+			s.Get(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func benchmarkGet(b *testing.B, n int, get func(int) (int, bool)) {
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			get(i % n)
+			i++
+		}
+	})
+}
+
+// BenchmarkGet_SingleLFU and BenchmarkGet_ShardedLFU16 compare a single-lock LFUCache
+// against a 16-shard ShardedLFU under parallel Get-heavy load, the workload sharding is
+// meant to help with: many goroutines reading concurrently, contending on one mutex.
+func BenchmarkGet_SingleLFU(b *testing.B) {
+	c := NewLFU[int, int](10000)
+	for i := 0; i < 10000; i++ {
+		c.Set(i, i)
+	}
+	benchmarkGet(b, 10000, c.Get)
+}
+
+func BenchmarkGet_ShardedLFU16(b *testing.B) {
+	s := NewShardedLFU[int, int](10000, 16)
+	for i := 0; i < 10000; i++ {
+		s.Set(i, i)
+	}
+	benchmarkGet(b, 10000, s.Get)
+}
@@ -0,0 +1,102 @@
+package incache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of a cache's counters, obtained via Cache.Stats.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Insertions  uint64
+	Expirations uint64
+
+	EvictionsCapacity uint64
+	EvictionsDeleted  uint64
+	EvictionsPurged   uint64
+
+	LoadCount   uint64
+	LoadErrors  uint64
+	LoadLatency time.Duration
+}
+
+// HitRatio returns Hits / (Hits + Misses), or 0 if Get has never been called.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// statsCounters holds the atomic counters backing a cache's Stats/ResetStats methods.
+// It is embedded by each cache implementation so the read path (Get) never has to take
+// the cache's mutex just to record a hit or a miss.
+type statsCounters struct {
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	insertions  atomic.Uint64
+	expirations atomic.Uint64
+
+	evictionsCapacity atomic.Uint64
+	evictionsDeleted  atomic.Uint64
+	evictionsPurged   atomic.Uint64
+
+	loadCount        atomic.Uint64
+	loadErrors       atomic.Uint64
+	loadLatencyNanos atomic.Int64
+}
+
+// recordEviction increments the counter matching reason. It is called unconditionally,
+// regardless of whether any OnEviction listener is registered. ReasonTransferred has no
+// counter and is not recorded.
+func (s *statsCounters) recordEviction(reason EvictionReason) {
+	switch reason {
+	case ReasonExpired:
+		s.expirations.Add(1)
+	case ReasonCapacity:
+		s.evictionsCapacity.Add(1)
+	case ReasonDeleted:
+		s.evictionsDeleted.Add(1)
+	case ReasonPurged:
+		s.evictionsPurged.Add(1)
+	}
+}
+
+// recordLoad tallies a GetOrLoad invocation of the configured loader.
+func (s *statsCounters) recordLoad(d time.Duration, err error) {
+	s.loadCount.Add(1)
+	if err != nil {
+		s.loadErrors.Add(1)
+	}
+	s.loadLatencyNanos.Add(int64(d))
+}
+
+func (s *statsCounters) snapshot() Stats {
+	return Stats{
+		Hits:              s.hits.Load(),
+		Misses:            s.misses.Load(),
+		Insertions:        s.insertions.Load(),
+		Expirations:       s.expirations.Load(),
+		EvictionsCapacity: s.evictionsCapacity.Load(),
+		EvictionsDeleted:  s.evictionsDeleted.Load(),
+		EvictionsPurged:   s.evictionsPurged.Load(),
+		LoadCount:         s.loadCount.Load(),
+		LoadErrors:        s.loadErrors.Load(),
+		LoadLatency:       time.Duration(s.loadLatencyNanos.Load()),
+	}
+}
+
+func (s *statsCounters) reset() {
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.insertions.Store(0)
+	s.expirations.Store(0)
+	s.evictionsCapacity.Store(0)
+	s.evictionsDeleted.Store(0)
+	s.evictionsPurged.Store(0)
+	s.loadCount.Store(0)
+	s.loadErrors.Store(0)
+	s.loadLatencyNanos.Store(0)
+}
@@ -0,0 +1,118 @@
+package incache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStats_HitsAndMisses(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, "one")
+		c.Get(1)
+		c.Get(1)
+		c.Get(2)
+
+		stats := c.Stats()
+		if stats.Hits != 2 {
+			t.Errorf("%s: Hits = %d, want 2", et, stats.Hits)
+		}
+		if stats.Misses != 1 {
+			t.Errorf("%s: Misses = %d, want 1", et, stats.Misses)
+		}
+		if got, want := stats.HitRatio(), 2.0/3.0; got != want {
+			t.Errorf("%s: HitRatio() = %v, want %v", et, got, want)
+		}
+	}
+}
+
+func TestStats_ExpirationsAndCapacityEvictions(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](2)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.SetWithTimeout(1, "one", time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		c.Get(1) // triggers lazy expiration
+
+		c.Set(2, "two")
+		c.Set(3, "three")
+		c.Set(4, "four") // capacity-2 cache: evicts one entry
+
+		stats := c.Stats()
+		if stats.Expirations != 1 {
+			t.Errorf("%s: Expirations = %d, want 1", et, stats.Expirations)
+		}
+		if stats.EvictionsCapacity == 0 {
+			t.Errorf("%s: EvictionsCapacity = 0, want > 0", et)
+		}
+	}
+}
+
+func TestStats_ManualDelete(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, "one")
+		c.Delete(1)
+
+		if got := c.Stats().EvictionsDeleted; got != 1 {
+			t.Errorf("%s: EvictionsDeleted = %d, want 1", et, got)
+		}
+	}
+}
+
+func TestStats_ResetStats(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, "one")
+		c.Get(1)
+		c.ResetStats()
+
+		stats := c.Stats()
+		if stats != (Stats{}) {
+			t.Errorf("%s: Stats() after ResetStats() = %+v, want zero value", et, stats)
+		}
+	}
+}
+
+func TestStats_Loader(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		calls := 0
+		b.Loader(func(k int) (string, time.Duration, error) {
+			calls++
+			if k == 2 {
+				return "", 0, errors.New("boom")
+			}
+			return "loaded", 0, nil
+		})
+		c := b.Build()
+
+		if _, err := c.GetOrLoad(1); err != nil {
+			t.Fatalf("%s: GetOrLoad(1) error = %v", et, err)
+		}
+		if _, err := c.GetOrLoad(2); err == nil {
+			t.Fatalf("%s: GetOrLoad(2) expected error", et)
+		}
+
+		stats := c.Stats()
+		if stats.LoadCount != 2 {
+			t.Errorf("%s: LoadCount = %d, want 2", et, stats.LoadCount)
+		}
+		if stats.LoadErrors != 1 {
+			t.Errorf("%s: LoadErrors = %d, want 1", et, stats.LoadErrors)
+		}
+	}
+}
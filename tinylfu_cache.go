@@ -0,0 +1,732 @@
+package incache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type tinyLFUSegment int
+
+const (
+	segWindow tinyLFUSegment = iota
+	segProbation
+	segProtected
+)
+
+type tinylfuItem[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt *time.Time
+	weight   uint64
+	segment  tinyLFUSegment
+}
+
+// TinyLFUCache is a W-TinyLFU cache: a small LRU "window" (~1% of capacity) admits new
+// entries, and a segmented-LRU "main" cache (~99%, split 20/80 between probation and
+// protected) holds entries that have proven themselves. When the window overflows, the
+// evicted candidate is only admitted into main if a Count-Min Sketch estimates it has been
+// accessed more often than main's own eviction victim, which is what makes TinyLFU resist
+// scan-heavy workloads (a one-off scan can't flush out genuinely hot keys) while staying
+// O(1) per operation. This follows Ben Manes' design for Caffeine.
+type TinyLFUCache[K comparable, V any] struct {
+	mu            sync.RWMutex
+	size          uint
+	windowCap     uint64
+	mainCap       uint64
+	protectedCap  uint64
+	windowUsed    uint64
+	probationUsed uint64
+	protectedUsed uint64
+	m             map[K]*list.Element
+	window        *list.List
+	probation     *list.List
+	protected     *list.List
+	sketch        *countMinSketch
+	loader        func(K) (V, time.Duration, error)
+	loaderGrp     loaderGroup[K, V]
+	events        *eventDispatcher[K, V]
+	weigher       func(K, V) uint64
+	usedCapacity  uint64
+	stats         statsCounters
+	janitor       *janitor
+	negCache      *negativeCache[K]
+	expQueue      *expirationQueue[K]
+}
+
+func NewTinyLFU[K comparable, V any](size uint) *TinyLFUCache[K, V] {
+	windowCap := uint64(size) / 100
+	if windowCap < 1 {
+		windowCap = 1
+	}
+	mainCap := uint64(size) - windowCap
+	protectedCap := mainCap * 8 / 10
+
+	return &TinyLFUCache[K, V]{
+		size:         size,
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: protectedCap,
+		m:            make(map[K]*list.Element),
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+		sketch:       newCountMinSketch(size),
+		weigher:      defaultWeigher[K, V],
+	}
+}
+
+// newTinyLFU builds a TinyLFUCache from a CacheBuilder, letting it be produced via
+// New[K,V](size).EvictType(TinyLFU).Build().
+func newTinyLFU[K comparable, V any](cacheBuilder *CacheBuilder[K, V]) *TinyLFUCache[K, V] {
+	c := NewTinyLFU[K, V](cacheBuilder.size)
+	c.loader = cacheBuilder.loader
+	c.weigher = resolveWeigher[K, V](cacheBuilder.weigher)
+	c.negCache = newNegativeCache[K](cacheBuilder.negativeTTL)
+	if cacheBuilder.janitorStrategy == SweepHeap {
+		c.expQueue = newExpirationQueue[K]()
+	}
+	c.janitor = startJanitor(cacheBuilder.janitorInterval, cacheBuilder.janitorStrategy, c)
+	if cacheBuilder.onInsert != nil || cacheBuilder.onEvict != nil {
+		c.events = newEventDispatcher[K, V]()
+		if cacheBuilder.onInsert != nil {
+			c.events.onInsertion(cacheBuilder.onInsert)
+		}
+		if cacheBuilder.onEvict != nil {
+			c.events.onEviction(cacheBuilder.onEvict)
+		}
+	}
+	return c
+}
+
+// OnInsertion registers fn to be called, on a background goroutine, whenever a key-value
+// pair is inserted into the cache. It returns a listener id usable with RemoveInsertionListener.
+func (c *TinyLFUCache[K, V]) OnInsertion(fn func(K, V)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onInsertion(fn)
+}
+
+// RemoveInsertionListener unregisters a listener previously added with OnInsertion.
+func (c *TinyLFUCache[K, V]) RemoveInsertionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeInsertionListener(id)
+	}
+}
+
+// OnEviction registers fn to be called, on a background goroutine, whenever a key-value
+// pair leaves the cache. It returns a listener id usable with RemoveEvictionListener.
+func (c *TinyLFUCache[K, V]) OnEviction(fn func(K, V, EvictionReason)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = newEventDispatcher[K, V]()
+	}
+	return c.events.onEviction(fn)
+}
+
+// RemoveEvictionListener unregisters a listener previously added with OnEviction.
+func (c *TinyLFUCache[K, V]) RemoveEvictionListener(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil {
+		c.events.removeEvictionListener(id)
+	}
+}
+
+// Get retrieves the value associated with the given key from the cache.
+// If the key is not found or has expired, it returns (zero value of V, false).
+// Otherwise, it returns (value, true), and records the access against both the
+// key's segment ordering and the frequency sketch used for admission decisions.
+func (c *TinyLFUCache[K, V]) Get(k K) (v V, b bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.m[k]
+	if !ok {
+		c.stats.misses.Add(1)
+		return
+	}
+
+	item := elem.Value.(*tinylfuItem[K, V])
+	if item.expireAt != nil && item.expireAt.Before(time.Now()) {
+		c.removeElement(elem)
+		c.stats.misses.Add(1)
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonExpired)
+		}
+		return
+	}
+
+	c.sketch.add(k)
+	c.recordAccess(elem, item)
+	c.stats.hits.Add(1)
+
+	return item.value, true
+}
+
+// recordAccess moves item within its current segment, or promotes it from probation to
+// protected, demoting protected's own LRU victim back to probation if that overflows
+// protected's capacity. Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) recordAccess(elem *list.Element, item *tinylfuItem[K, V]) {
+	switch item.segment {
+	case segWindow:
+		c.window.MoveToFront(elem)
+	case segProtected:
+		c.protected.MoveToFront(elem)
+	case segProbation:
+		c.probation.Remove(elem)
+		c.probationUsed -= item.weight
+		item.segment = segProtected
+		c.m[item.key] = c.protected.PushFront(item)
+		c.protectedUsed += item.weight
+		c.demoteProtectedOverflow()
+	}
+}
+
+// demoteProtectedOverflow pushes protected's coldest entries back to probation until
+// protected is back within its capacity. Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) demoteProtectedOverflow() {
+	for c.protectedUsed > c.protectedCap {
+		back := c.protected.Back()
+		if back == nil {
+			return
+		}
+		item := back.Value.(*tinylfuItem[K, V])
+		c.protected.Remove(back)
+		c.protectedUsed -= item.weight
+		item.segment = segProbation
+		c.m[item.key] = c.probation.PushFront(item)
+		c.probationUsed += item.weight
+	}
+}
+
+// GetAll retrieves all key-value pairs from the cache.
+// It returns a map containing all the key-value pairs that are not expired.
+func (c *TinyLFUCache[K, V]) GetAll() map[K]V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := make(map[K]V)
+	for k, elem := range c.m {
+		item := elem.Value.(*tinylfuItem[K, V])
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			m[k] = item.value
+		}
+	}
+
+	return m
+}
+
+// Set adds the key-value pair to the cache.
+func (c *TinyLFUCache[K, V]) Set(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(k, v, 0)
+}
+
+// SetWithTimeout adds the key-value pair to the cache with a specified expiration time.
+func (c *TinyLFUCache[K, V]) SetWithTimeout(k K, v V, t time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(k, v, t)
+}
+
+// NotFoundSet adds the key-value pair to the cache only if the key does not exist.
+// It returns true if the key was added to the cache, otherwise false.
+func (c *TinyLFUCache[K, V]) NotFoundSet(k K, v V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.m[k]; ok {
+		return false
+	}
+
+	c.set(k, v, 0)
+	return true
+}
+
+// NotFoundSetWithTimeout adds the key-value pair to the cache only if the key does not exist.
+// It sets an expiration time for the key-value pair.
+// It returns true if the key was added to the cache, otherwise false.
+func (c *TinyLFUCache[K, V]) NotFoundSetWithTimeout(k K, v V, t time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.m[k]; ok {
+		return false
+	}
+
+	c.set(k, v, t)
+	return true
+}
+
+// GetOrLoad retrieves the value associated with the given key, invoking the configured
+// loader on a miss. Concurrent calls for the same key coalesce into a single loader invocation.
+// It returns ErrNoLoader if no loader was configured via CacheBuilder.Loader.
+func (c *TinyLFUCache[K, V]) GetOrLoad(k K) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	if err, ok := c.negCache.get(k); ok {
+		var zero V
+		return zero, err
+	}
+	if c.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	var ttl time.Duration
+	var loaded bool
+	return c.loaderGrp.do(k, func() (V, error) {
+		if v, ok := c.Get(k); ok {
+			return v, nil
+		}
+		if err, ok := c.negCache.get(k); ok {
+			var zero V
+			return zero, err
+		}
+		start := time.Now()
+		v, d, err := c.loader(k)
+		c.stats.recordLoad(time.Since(start), err)
+		ttl = d
+		loaded = true
+		return v, err
+	}, func(v V, err error, fresh bool) {
+		if !loaded || !fresh {
+			return
+		}
+		if err != nil {
+			c.negCache.set(k, err)
+			return
+		}
+		c.negCache.clear(k)
+		c.SetWithTimeout(k, v, ttl)
+	})
+}
+
+// Delete removes the key-value pair associated with the given key from the cache.
+func (c *TinyLFUCache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.delete(k)
+}
+
+func (c *TinyLFUCache[K, V]) delete(k K) {
+	c.loaderGrp.bump(k)
+	c.negCache.clear(k)
+	elem, ok := c.m[k]
+	if !ok {
+		return
+	}
+
+	item := elem.Value.(*tinylfuItem[K, V])
+	c.removeElement(elem)
+	c.stats.recordEviction(ReasonDeleted)
+	if c.events != nil {
+		c.events.fireEviction(k, item.value, ReasonDeleted)
+	}
+}
+
+// removeElement deletes elem from whichever segment list it belongs to and from the
+// lookup map, and unwinds its weight from both that segment's and the overall usedCapacity
+// counters. Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) removeElement(elem *list.Element) {
+	item := elem.Value.(*tinylfuItem[K, V])
+	delete(c.m, item.key)
+	switch item.segment {
+	case segWindow:
+		c.window.Remove(elem)
+		c.windowUsed -= item.weight
+	case segProbation:
+		c.probation.Remove(elem)
+		c.probationUsed -= item.weight
+	case segProtected:
+		c.protected.Remove(elem)
+		c.protectedUsed -= item.weight
+	}
+	c.usedCapacity -= item.weight
+	c.expQueue.remove(item.key)
+}
+
+// TransferTo transfers all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *TinyLFUCache[K, V]) TransferTo(dst Cache[K, V]) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	for k, elem := range src.m {
+		item := elem.Value.(*tinylfuItem[K, V])
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
+			src.stats.recordEviction(ReasonTransferred)
+			if src.events != nil {
+				src.events.fireEviction(k, item.value, ReasonTransferred)
+			}
+		}
+	}
+	src.m = make(map[K]*list.Element)
+	src.window.Init()
+	src.probation.Init()
+	src.protected.Init()
+	src.windowUsed, src.probationUsed, src.protectedUsed, src.usedCapacity = 0, 0, 0, 0
+	if src.expQueue != nil {
+		src.expQueue = newExpirationQueue[K]()
+	}
+}
+
+// CopyTo copies all non-expired key-value pairs from the source cache to the provided destination cache.
+func (src *TinyLFUCache[K, V]) CopyTo(dst Cache[K, V]) {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	for k, elem := range src.m {
+		item := elem.Value.(*tinylfuItem[K, V])
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			dst.setValueWithTimeout(k, valueWithTimeout[V]{value: item.value, expireAt: item.expireAt})
+		}
+	}
+}
+
+// setValueWithTimeout inserts a value with an absolute expiration time, used internally by
+// TransferTo/CopyTo to move entries between cache implementations without losing their
+// remaining TTL. The entry always re-enters through the window, same as any new Set.
+func (c *TinyLFUCache[K, V]) setValueWithTimeout(k K, v valueWithTimeout[V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.insert(k, v.value, v.expireAt)
+}
+
+// Keys returns a slice of all keys currently stored in the cache.
+// The returned slice does not include expired keys.
+// The order of keys in the slice is not guaranteed.
+func (c *TinyLFUCache[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]K, 0, len(c.m))
+	for k, elem := range c.m {
+		item := elem.Value.(*tinylfuItem[K, V])
+		if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Purge removes all key-value pairs from the cache.
+func (c *TinyLFUCache[K, V]) Purge() {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, elem := range c.m {
+		item := elem.Value.(*tinylfuItem[K, V])
+		c.stats.recordEviction(ReasonPurged)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonPurged)
+		}
+	}
+	if c.events != nil {
+		c.events.stop()
+	}
+
+	c.m = make(map[K]*list.Element)
+	c.window.Init()
+	c.probation.Init()
+	c.protected.Init()
+	c.windowUsed, c.probationUsed, c.protectedUsed, c.usedCapacity = 0, 0, 0, 0
+	if c.expQueue != nil {
+		c.expQueue = newExpirationQueue[K]()
+	}
+}
+
+// Count returns the number of non-expired key-value pairs currently stored in the cache.
+func (c *TinyLFUCache[K, V]) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var count int
+	for _, elem := range c.m {
+		if item := elem.Value.(*tinylfuItem[K, V]); item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Len returns the number of elements in the cache.
+func (c *TinyLFUCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.m)
+}
+
+// expireIfPast removes k if it has expired, firing a ReasonExpired eviction and recording
+// it in stats. Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) expireIfPast(k K) bool {
+	elem, ok := c.m[k]
+	if !ok {
+		return false
+	}
+	item := elem.Value.(*tinylfuItem[K, V])
+	if item.expireAt == nil || !item.expireAt.Before(time.Now()) {
+		return false
+	}
+	c.removeElement(elem)
+	c.stats.recordEviction(ReasonExpired)
+	if c.events != nil {
+		c.events.fireEviction(k, item.value, ReasonExpired)
+	}
+	return true
+}
+
+// nextExpiry returns the expiry time of the soonest-expiring entry still tracked in the
+// cache's expirationQueue, for janitor.runHeap. Only meaningful when built with SweepHeap.
+func (c *TinyLFUCache[K, V]) nextExpiry() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, expireAt, ok := c.expQueue.peek()
+	return expireAt, ok
+}
+
+// sweepDue removes every entry whose expiry has already passed, for janitor.runHeap.
+func (c *TinyLFUCache[K, V]) sweepDue() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for {
+		k, expireAt, ok := c.expQueue.peek()
+		if !ok || expireAt.After(now) {
+			return
+		}
+		c.expQueue.pop()
+		elem, ok := c.m[k]
+		if !ok {
+			continue
+		}
+		item := elem.Value.(*tinylfuItem[K, V])
+		c.removeElement(elem)
+		c.stats.recordEviction(ReasonExpired)
+		if c.events != nil {
+			c.events.fireEviction(k, item.value, ReasonExpired)
+		}
+	}
+}
+
+// sweepFull removes every currently expired entry, processing at most batchSize entries
+// per lock acquisition so the janitor never holds the write lock for the whole cache.
+func (c *TinyLFUCache[K, V]) sweepFull(batchSize int) {
+	c.mu.RLock()
+	keys := make([]K, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		c.mu.Lock()
+		for _, k := range keys[start:end] {
+			c.expireIfPast(k)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// sweepSampled inspects up to n entries, chosen via Go's randomized map iteration order,
+// and removes the ones that have expired.
+func (c *TinyLFUCache[K, V]) sweepSampled(n int) (sampled, expired int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.m {
+		if sampled >= n {
+			break
+		}
+		sampled++
+		if c.expireIfPast(k) {
+			expired++
+		}
+	}
+	return
+}
+
+func (c *TinyLFUCache[K, V]) set(k K, v V, exp time.Duration) {
+	var tm *time.Time
+	if exp > 0 {
+		t := time.Now().Add(exp)
+		tm = &t
+	}
+	c.insert(k, v, tm)
+}
+
+// insert replaces any existing entry for k, then always admits the new entry through the
+// window, firing insertion and (if the key already existed) eviction events, and updates
+// usedCapacity bookkeeping. It does not touch the frequency sketch, which only Get feeds.
+// Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) insert(k K, v V, tm *time.Time) {
+	c.loaderGrp.bump(k)
+	weight := c.weigher(k, v)
+
+	if elem, ok := c.m[k]; ok {
+		old := elem.Value.(*tinylfuItem[K, V])
+		c.removeElement(elem)
+		c.stats.recordEviction(ReasonDeleted)
+		if c.events != nil {
+			c.events.fireEviction(k, old.value, ReasonDeleted)
+		}
+	}
+
+	item := &tinylfuItem[K, V]{key: k, value: v, expireAt: tm, weight: weight, segment: segWindow}
+	c.m[k] = c.window.PushFront(item)
+	c.windowUsed += weight
+	c.usedCapacity += weight
+	c.stats.insertions.Add(1)
+	if tm != nil {
+		c.expQueue.push(k, *tm)
+		c.janitor.wake()
+	} else {
+		c.expQueue.remove(k)
+	}
+	if c.events != nil {
+		c.events.fireInsertion(k, v)
+	}
+
+	c.admitFromWindow()
+}
+
+// admitFromWindow moves candidates evicted from the overflowing window into main,
+// admitting each one only if the frequency sketch judges it hotter than main's own
+// eviction victim (the coldest entry in probation, falling back to protected if
+// probation is empty). A rejected candidate is evicted outright. Callers must hold c.mu.
+func (c *TinyLFUCache[K, V]) admitFromWindow() {
+	for c.windowUsed > c.windowCap {
+		back := c.window.Back()
+		if back == nil {
+			return
+		}
+		candidate := back.Value.(*tinylfuItem[K, V])
+		c.window.Remove(back)
+		c.windowUsed -= candidate.weight
+		delete(c.m, candidate.key)
+		c.usedCapacity -= candidate.weight
+
+		if c.probationUsed+c.protectedUsed+candidate.weight <= c.mainCap {
+			c.admitToProbation(candidate)
+			continue
+		}
+
+		victimElem := c.probation.Back()
+		if victimElem == nil {
+			victimElem = c.protected.Back()
+		}
+		if victimElem == nil {
+			c.admitToProbation(candidate)
+			continue
+		}
+		victim := victimElem.Value.(*tinylfuItem[K, V])
+
+		if c.sketch.estimate(candidate.key) > c.sketch.estimate(victim.key) {
+			c.removeElement(victimElem)
+			c.stats.recordEviction(ReasonCapacity)
+			if c.events != nil {
+				c.events.fireEviction(victim.key, victim.value, ReasonCapacity)
+			}
+			c.admitToProbation(candidate)
+		} else {
+			c.expQueue.remove(candidate.key)
+			c.stats.recordEviction(ReasonCapacity)
+			if c.events != nil {
+				c.events.fireEviction(candidate.key, candidate.value, ReasonCapacity)
+			}
+		}
+	}
+}
+
+func (c *TinyLFUCache[K, V]) admitToProbation(item *tinylfuItem[K, V]) {
+	item.segment = segProbation
+	c.m[item.key] = c.probation.PushFront(item)
+	c.probationUsed += item.weight
+	c.usedCapacity += item.weight
+}
+
+// TrySet adds or updates the key-value pair in the cache, like Set, but rejects the entry
+// with ErrWeightExceedsCapacity if its weight alone exceeds the cache's capacity.
+func (c *TinyLFUCache[K, V]) TrySet(k K, v V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.weigher(k, v) > uint64(c.size) {
+		return ErrWeightExceedsCapacity
+	}
+
+	c.set(k, v, 0)
+	return nil
+}
+
+// Used returns the total weight of all entries currently stored in the cache.
+func (c *TinyLFUCache[K, V]) Used() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usedCapacity
+}
+
+// Capacity returns the cache's capacity in weight units, as configured by New.
+func (c *TinyLFUCache[K, V]) Capacity() uint64 {
+	return uint64(c.size)
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction/load counters.
+func (c *TinyLFUCache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the cache's hit/miss/eviction/load counters.
+func (c *TinyLFUCache[K, V]) ResetStats() {
+	c.stats.reset()
+}
+
+// evict evicts i entries, preferring probation's coldest entry, then protected's, then
+// the window's, mirroring the order admitFromWindow would sacrifice them in under
+// capacity pressure.
+func (c *TinyLFUCache[K, V]) evict(i int) {
+	for j := 0; j < i; j++ {
+		elem := c.probation.Back()
+		if elem == nil {
+			elem = c.protected.Back()
+		}
+		if elem == nil {
+			elem = c.window.Back()
+		}
+		if elem == nil {
+			return
+		}
+		item := elem.Value.(*tinylfuItem[K, V])
+		c.removeElement(elem)
+		c.stats.recordEviction(ReasonCapacity)
+		if c.events != nil {
+			c.events.fireEviction(item.key, item.value, ReasonCapacity)
+		}
+	}
+}
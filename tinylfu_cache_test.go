@@ -0,0 +1,149 @@
+package incache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSet_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.Set("key1", "value1")
+	if v, ok := c.Get("key1"); !ok || v != "value1" {
+		t.Errorf("Set failed")
+	}
+}
+
+func TestGet_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.Set("key1", "value1")
+	if v, ok := c.Get("key1"); !ok || v != "value1" {
+		t.Errorf("Get failed")
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Errorf("expected a miss for an unset key")
+	}
+}
+
+func TestSetWithTimeout_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.SetWithTimeout("key1", "value1", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("expected key1 to have expired")
+	}
+}
+
+func TestNotFoundSet_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	if !c.NotFoundSet("key1", "value1") {
+		t.Errorf("NotFoundSet failed")
+	}
+	if c.NotFoundSet("key1", "value2") {
+		t.Errorf("NotFoundSet failed")
+	}
+}
+
+func TestDelete_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Delete("key1")
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("Delete failed")
+	}
+}
+
+func TestPurge_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Set("key2", "value2")
+	c.Purge()
+
+	if _, ok := c.Get("key1"); ok {
+		t.Errorf("Purge failed")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len: expected: 0, got: %d", c.Len())
+	}
+}
+
+func TestCount_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](10)
+
+	c.Set("key1", "value1")
+	c.Set("key2", "value2")
+
+	if c.Count() != 2 {
+		t.Errorf("Count failed")
+	}
+}
+
+func TestTrySet_RejectsOversizedEntry_TinyLFU(t *testing.T) {
+	c := New[int, string](5)
+	c.EvictType(TinyLFU)
+	c.Weigher(func(k int, v string) uint64 {
+		return uint64(len(v))
+	})
+	built := c.Build()
+
+	if err := built.TrySet(1, "this is way too long"); err != ErrWeightExceedsCapacity {
+		t.Errorf("TrySet() error = %v, want ErrWeightExceedsCapacity", err)
+	}
+}
+
+func TestBuild_TinyLFU(t *testing.T) {
+	b := New[string, string](10)
+	b.EvictType(TinyLFU)
+	c := b.Build()
+
+	if _, ok := c.(*TinyLFUCache[string, string]); !ok {
+		t.Errorf("expected Build with EvictType(TinyLFU) to return a *TinyLFUCache")
+	}
+}
+
+// TestFrequentKeysSurviveScan_TinyLFU is the defining property of W-TinyLFU: a handful of
+// frequently-accessed "hot" keys, once promoted into the protected segment, must survive a
+// large burst of one-off "scan" keys that would otherwise flush them out of a plain LRU.
+func TestFrequentKeysSurviveScan_TinyLFU(t *testing.T) {
+	c := NewTinyLFU[string, string](150)
+
+	const keepCount = 5
+	for i := 0; i < keepCount; i++ {
+		c.Set(fmt.Sprintf("keep%d", i), "hot")
+	}
+	c.Set("flush-window", "x") // pushes the last "keep" key out of the window and into probation
+
+	for i := 0; i < keepCount; i++ {
+		key := fmt.Sprintf("keep%d", i)
+		for j := 0; j < 3; j++ {
+			if _, ok := c.Get(key); !ok {
+				t.Fatalf("expected %s to still be present while warming it up", key)
+			}
+		}
+	}
+
+	// Fill main to capacity with filler keys so later scan keys must contend for admission.
+	for i := 0; i < 300; i++ {
+		c.Set(fmt.Sprintf("filler%d", i), "f")
+	}
+
+	// A large one-off scan: each key is inserted once and never read again.
+	for i := 0; i < 300; i++ {
+		c.Set(fmt.Sprintf("scan%d", i), "s")
+	}
+
+	for i := 0; i < keepCount; i++ {
+		key := fmt.Sprintf("keep%d", i)
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("expected frequently-accessed %s to survive the scan, but it was evicted", key)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package incache
+
+import "testing"
+
+func TestWeigher_EvictsByWeightNotCount(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](10)
+		b.EvictType(et)
+		b.Weigher(func(k int, v string) uint64 {
+			return uint64(len(v))
+		})
+		c := b.Build()
+
+		c.Set(1, "hello") // weight 5
+		c.Set(2, "world") // weight 5, usedCapacity 10
+		if got := c.Used(); got != 10 {
+			t.Fatalf("%s: Used() = %d, want 10", et, got)
+		}
+
+		c.Set(3, "x") // weight 1: should evict to make room
+		if got := c.Used(); got > 10 {
+			t.Errorf("%s: Used() = %d, want <= 10", et, got)
+		}
+		if c.Count() >= 3 {
+			t.Errorf("%s: expected an eviction to make room, got Count() = %d", et, c.Count())
+		}
+	}
+}
+
+func TestWeigher_DefaultIsEntryCount(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](2)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, "a")
+		c.Set(2, "b")
+		if got := c.Used(); got != 2 {
+			t.Errorf("%s: Used() = %d, want 2", et, got)
+		}
+		if got := c.Capacity(); got != 2 {
+			t.Errorf("%s: Capacity() = %d, want 2", et, got)
+		}
+	}
+}
+
+type blob struct {
+	bytes []byte
+}
+
+func (b blob) Size() int64 {
+	return int64(len(b.bytes))
+}
+
+func TestWeigher_SizeableIsUsedWhenNoWeigherConfigured(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, blob](10)
+		b.EvictType(et)
+		c := b.Build()
+
+		c.Set(1, blob{bytes: make([]byte, 6)})
+		if got := c.Used(); got != 6 {
+			t.Fatalf("%s: Used() = %d, want 6 from Sizeable.Size()", et, got)
+		}
+
+		c.Set(2, blob{bytes: make([]byte, 5)}) // pushes usedCapacity to 11, over size 10
+		if got := c.Used(); got > 10 {
+			t.Errorf("%s: Used() = %d, want <= 10", et, got)
+		}
+	}
+}
+
+func TestWeigher_ExplicitWeigherOverridesSizeable(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, blob](10)
+		b.EvictType(et)
+		b.Weigher(func(k int, v blob) uint64 {
+			return 1
+		})
+		c := b.Build()
+
+		c.Set(1, blob{bytes: make([]byte, 100)})
+		if got := c.Used(); got != 1 {
+			t.Errorf("%s: Used() = %d, want 1 from explicit Weigher, not Sizeable.Size()", et, got)
+		}
+	}
+}
+
+func TestTrySet_RejectsOversizedEntry(t *testing.T) {
+	for _, et := range []EvictType{Manual, LRU, LFU, FIFO} {
+		b := New[int, string](5)
+		b.EvictType(et)
+		b.Weigher(func(k int, v string) uint64 {
+			return uint64(len(v))
+		})
+		c := b.Build()
+
+		if err := c.TrySet(1, "this is way too long"); err != ErrWeightExceedsCapacity {
+			t.Errorf("%s: TrySet() error = %v, want ErrWeightExceedsCapacity", et, err)
+		}
+		if c.Count() != 0 {
+			t.Errorf("%s: expected rejected entry not to be stored, got Count() = %d", et, c.Count())
+		}
+
+		if err := c.TrySet(2, "fits"); err != nil {
+			t.Errorf("%s: TrySet() error = %v, want nil", et, err)
+		}
+		if _, ok := c.Get(2); !ok {
+			t.Errorf("%s: expected entry accepted by TrySet to be retrievable", et)
+		}
+	}
+}